@@ -5,8 +5,10 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	ovn "github.com/mstinsky/ovn-exporter/ovnmonitor"
@@ -24,13 +26,18 @@ func main() {
 	}
 
 	exporter := ovn.NewExporter(config)
-	if err = exporter.StartConnection(); err != nil {
-		slog.Error("failed to connect db socket", "error", err)
-		go exporter.TryClientConnection()
-	}
-	exporter.StartOvnMetrics()
+	exporter.SelfCheck()
+	exporter.StartSupervisor()
+	prometheus.MustRegister(exporter)
 	mux := http.NewServeMux()
 	mux.Handle(config.MetricsPath, promhttp.Handler())
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		if disconnected := exporter.Healthy(); len(disconnected) > 0 {
+			http.Error(w, fmt.Sprintf("not connected to %s", strings.Join(disconnected, ", ")), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
 	slog.Info(fmt.Sprintf("Listening on %s", config.ListenAddress))
 
 	// conform to Gosec G114
@@ -0,0 +1,255 @@
+package ovnmonitor
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The parsers in this file read the statistics that OVS and OVN daemons
+// print in reply to unixctl commands. Lines they do not understand are
+// skipped, so a new field in a future version does not break the others.
+
+var coverageLineRegex = regexp.MustCompile(`^(\S+)\s+.*total:\s*(\d+)\s*$`)
+
+// parseCoverage parses the output of coverage/show into the total count of
+// every event, e.g. from
+// `hmap_expand   0.0/sec   0.050/sec   0.0100/sec   total: 1037`.
+func parseCoverage(output string) map[string]float64 {
+	events := make(map[string]float64)
+	for _, line := range strings.Split(output, "\n") {
+		m := coverageLineRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		if value, err := strconv.ParseFloat(m[2], 64); err == nil {
+			events[m[1]] = value
+		}
+	}
+	return events
+}
+
+var memoryItemRegex = regexp.MustCompile(`(\S+):(\d+)`)
+
+// parseMemory parses the output of memory/show into the count of every
+// item, e.g. from `atoms:15362 cells:18410 monitors:6 sessions:4`.
+func parseMemory(output string) map[string]float64 {
+	items := make(map[string]float64)
+	for _, m := range memoryItemRegex.FindAllStringSubmatch(output, -1) {
+		if value, err := strconv.ParseFloat(m[2], 64); err == nil {
+			items[m[1]] = value
+		}
+	}
+	return items
+}
+
+// stopwatchStats holds the statistics of a stopwatch, durations in seconds.
+type stopwatchStats struct {
+	samples float64
+	// durations maps max, min, p95, short_term_avg and long_term_avg to
+	// their value.
+	durations map[string]float64
+}
+
+var stopwatchFields = map[string]string{
+	"Maximum":            "max",
+	"Minimum":            "min",
+	"95th percentile":    "p95",
+	"Short term average": "short_term_avg",
+	"Long term average":  "long_term_avg",
+}
+
+// parseStopwatches parses the output of stopwatch/show, which is of the
+// format
+//
+//	Statistics for 'ovnnb_db_run'
+//	  Total samples: 45
+//	  Maximum: 38 msec
+//	  95th percentile: 13.371 msec
+//	  ...
+func parseStopwatches(output string) map[string]*stopwatchStats {
+	stopwatches := make(map[string]*stopwatchStats)
+	var current *stopwatchStats
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if name, ok := strings.CutPrefix(line, "Statistics for "); ok {
+			current = &stopwatchStats{durations: make(map[string]float64)}
+			stopwatches[strings.Trim(name, "'")] = current
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if current == nil || !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		number, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+		if key == "Total samples" {
+			current.samples = number
+			continue
+		}
+		field, ok := stopwatchFields[key]
+		if !ok {
+			continue
+		}
+		unit := "msec"
+		if len(fields) > 1 {
+			unit = fields[1]
+		}
+		switch unit {
+		case "usec":
+			number /= 1e6
+		case "nsec":
+			number /= 1e9
+		default:
+			number /= 1e3
+		}
+		current.durations[field] = number
+	}
+	return stopwatches
+}
+
+var incEngineStatRegex = regexp.MustCompile(`^-?\s*(\w+)\s*:\s*(\d+)$`)
+
+// parseIncEngineStats parses the output of inc-engine/show-stats into the
+// run counters of every engine node, which is of the format
+//
+//	Node: northd
+//	- recompute:          12
+//	- compute:             0
+//	- cancel:              0
+func parseIncEngineStats(output string) map[string]map[string]float64 {
+	nodes := make(map[string]map[string]float64)
+	var current map[string]float64
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if name, ok := strings.CutPrefix(line, "Node:"); ok {
+			current = make(map[string]float64)
+			nodes[strings.TrimSpace(name)] = current
+			continue
+		}
+		m := incEngineStatRegex.FindStringSubmatch(line)
+		if current == nil || m == nil {
+			continue
+		}
+		if value, err := strconv.ParseFloat(m[2], 64); err == nil {
+			current[m[1]] = value
+		}
+	}
+	return nodes
+}
+
+// parseLflowCacheStats parses the output of lflow-cache/show-stats into its
+// statistics keyed as printed, Enabled is 1 or 0. The output is of the
+// format
+//
+//	Enabled: true
+//	high-watermark  : 1052
+//	cache-expr      : 1049
+//	trim count      : 0
+//	Mem usage (KB)  : 445
+func parseLflowCacheStats(output string) map[string]float64 {
+	stats := make(map[string]float64)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch value {
+		case "true":
+			stats[key] = 1
+		case "false":
+			stats[key] = 0
+		default:
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				stats[key] = number
+			}
+		}
+	}
+	return stats
+}
+
+var datapathLineRegex = regexp.MustCompile(`^(\S+@\S+):\s*(.*)$`)
+
+// parseDpifShow parses the output of dpif/show into the lookup counters
+// (hit, missed and lost) of every datapath, e.g. from
+// `system@ovs-system: hit:14657 missed:1187`. The ports listed below each
+// datapath are skipped.
+func parseDpifShow(output string) map[string]map[string]float64 {
+	datapaths := make(map[string]map[string]float64)
+	for _, line := range strings.Split(output, "\n") {
+		m := datapathLineRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		datapaths[m[1]] = parseMemory(m[2])
+	}
+	return datapaths
+}
+
+// upcallStats holds the flow statistics of a datapath from upcall/show.
+type upcallStats struct {
+	// flows maps current, avg, max and limit to the number of flows.
+	flows map[string]float64
+	// dumpDuration is the duration of the last flow dump in seconds, it is
+	// negative when not reported.
+	dumpDuration float64
+	// handlerKeys maps each revalidator to the number of flow keys it
+	// handles.
+	handlerKeys map[string]float64
+}
+
+var (
+	upcallFlowsRegex   = regexp.MustCompile(`\((current|avg|max|limit) (\d+)\)`)
+	upcallHandlerRegex = regexp.MustCompile(`^(\d+): \(keys (\d+)\)$`)
+)
+
+// parseUpcallShow parses the output of upcall/show, which is of the format
+//
+//	system@ovs-system:
+//	  flows         : (current 23) (avg 22) (max 120) (limit 200000)
+//	  dump duration : 1ms
+//	  ufid enabled : true
+//
+//	  4: (keys 12)
+func parseUpcallShow(output string) map[string]*upcallStats {
+	datapaths := make(map[string]*upcallStats)
+	var current *upcallStats
+	for _, line := range strings.Split(output, "\n") {
+		if m := datapathLineRegex.FindStringSubmatch(line); m != nil {
+			current = &upcallStats{flows: make(map[string]float64), dumpDuration: -1, handlerKeys: make(map[string]float64)}
+			datapaths[m[1]] = current
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		line = strings.TrimSpace(line)
+		key, value, _ := strings.Cut(line, ":")
+		switch strings.TrimSpace(key) {
+		case "flows":
+			for _, m := range upcallFlowsRegex.FindAllStringSubmatch(value, -1) {
+				if number, err := strconv.ParseFloat(m[2], 64); err == nil {
+					current.flows[m[1]] = number
+				}
+			}
+		case "dump duration":
+			if number, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "ms"), 64); err == nil {
+				current.dumpDuration = number / 1e3
+			}
+		default:
+			if m := upcallHandlerRegex.FindStringSubmatch(line); m != nil {
+				if number, err := strconv.ParseFloat(m[2], 64); err == nil {
+					current.handlerKeys[m[1]] = number
+				}
+			}
+		}
+	}
+	return datapaths
+}
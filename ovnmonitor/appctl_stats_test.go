@@ -0,0 +1,260 @@
+package ovnmonitor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCoverage(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   map[string]float64
+	}{
+		{
+			name: "ovsdb-server",
+			output: `Event coverage, avg rate over last: 5 seconds, last minute, last hour,  hash=e8a8ee46:
+hmap_pathological          0.0/sec     0.000/sec        0.0000/sec   total: 5
+hmap_expand                0.0/sec     0.050/sec        0.0100/sec   total: 1037
+lflow_run                  0.2/sec     0.200/sec        0.2000/sec   total: 360
+112 events never hit
+`,
+			want: map[string]float64{"hmap_pathological": 5, "hmap_expand": 1037, "lflow_run": 360},
+		},
+		{
+			name:   "nothing hit",
+			output: "Event coverage, avg rate over last: 5 seconds, last minute, last hour,  hash=00000000:\n115 events never hit\n",
+			want:   map[string]float64{},
+		},
+	}
+	for _, tt := range tests {
+		if got := parseCoverage(tt.output); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: parseCoverage() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseMemory(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   map[string]float64
+	}{
+		{
+			name:   "ovsdb-server",
+			output: "atoms:15362 cells:18410 json-caches:2 monitors:6 raft-connections:4 raft-log:1108 sessions:4 txn-history:100 txn-history-atoms:2000 backlog:0\n",
+			want: map[string]float64{
+				"atoms": 15362, "cells": 18410, "json-caches": 2, "monitors": 6, "raft-connections": 4,
+				"raft-log": 1108, "sessions": 4, "txn-history": 100, "txn-history-atoms": 2000, "backlog": 0,
+			},
+		},
+		{
+			name:   "ovn-controller",
+			output: "lflow-cache-entries-cache-expr:1049 lflow-cache-entries-cache-matches:3 lflow-cache-size-KB:445 ofctrl_desired_flow_usage-KB:120\n",
+			want: map[string]float64{
+				"lflow-cache-entries-cache-expr": 1049, "lflow-cache-entries-cache-matches": 3,
+				"lflow-cache-size-KB": 445, "ofctrl_desired_flow_usage-KB": 120,
+			},
+		},
+		{
+			name:   "empty",
+			output: "",
+			want:   map[string]float64{},
+		},
+	}
+	for _, tt := range tests {
+		if got := parseMemory(tt.output); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: parseMemory() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseStopwatches(t *testing.T) {
+	// the parser converts with a division, so do the expectations
+	msec := func(v float64) float64 { return v / 1e3 }
+	usec := func(v float64) float64 { return v / 1e6 }
+
+	output := `Statistics for 'ovnnb_db_run'
+  Total samples: 45
+  Maximum: 38 msec
+  Minimum: 0 msec
+  95th percentile: 13.371 msec
+  Short term average: 4.3 msec
+  Long term average: 2.9 msec
+Statistics for 'build_lflows'
+  Total samples: 40
+  Maximum: 250 usec
+  Minimum: 10 usec
+  95th percentile: 200.5 usec
+  Short term average: 100 usec
+  Long term average: 90 usec
+Statistics for 'ovnsb_db_run'
+  Total samples: 0
+`
+	want := map[string]*stopwatchStats{
+		"ovnnb_db_run": {samples: 45, durations: map[string]float64{
+			"max": msec(38), "min": msec(0), "p95": msec(13.371), "short_term_avg": msec(4.3), "long_term_avg": msec(2.9),
+		}},
+		"build_lflows": {samples: 40, durations: map[string]float64{
+			"max": usec(250), "min": usec(10), "p95": usec(200.5), "short_term_avg": usec(100), "long_term_avg": usec(90),
+		}},
+		"ovnsb_db_run": {samples: 0, durations: map[string]float64{}},
+	}
+	got := parseStopwatches(output)
+	if len(got) != len(want) {
+		t.Fatalf("parseStopwatches() returned %d stopwatches, want %d", len(got), len(want))
+	}
+	for name, w := range want {
+		if g, ok := got[name]; !ok || !reflect.DeepEqual(g, w) {
+			t.Errorf("parseStopwatches()[%q] = %+v, want %+v", name, g, w)
+		}
+	}
+}
+
+func TestParseIncEngineStats(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   map[string]map[string]float64
+	}{
+		{
+			name: "ovn-northd",
+			output: `Node: northd
+- recompute:          12
+- compute:             3
+- cancel:              0
+Node: lflow
+- recompute:          10
+- compute:             5
+- cancel:              1
+`,
+			want: map[string]map[string]float64{
+				"northd": {"recompute": 12, "compute": 3, "cancel": 0},
+				"lflow":  {"recompute": 10, "compute": 5, "cancel": 1},
+			},
+		},
+		{
+			name: "ovn-controller",
+			output: `Node: SB_chassis
+- recompute:           1
+- compute:             0
+- cancel:              0
+Node: runtime_data
+- recompute:           2
+- compute:            57
+- cancel:              4
+`,
+			want: map[string]map[string]float64{
+				"SB_chassis":   {"recompute": 1, "compute": 0, "cancel": 0},
+				"runtime_data": {"recompute": 2, "compute": 57, "cancel": 4},
+			},
+		},
+		{
+			name:   "stats before the first node",
+			output: "- recompute: 1\n",
+			want:   map[string]map[string]float64{},
+		},
+	}
+	for _, tt := range tests {
+		if got := parseIncEngineStats(tt.output); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: parseIncEngineStats() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseLflowCacheStats(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   map[string]float64
+	}{
+		{
+			name: "enabled",
+			output: `Enabled: true
+high-watermark  : 1052
+total           : 1052
+cache-conj-id   : 0
+cache-expr      : 1049
+cache-matches   : 3
+trim count      : 2
+Mem usage (KB)  : 445
+`,
+			want: map[string]float64{
+				"Enabled": 1, "high-watermark": 1052, "total": 1052, "cache-conj-id": 0,
+				"cache-expr": 1049, "cache-matches": 3, "trim count": 2, "Mem usage (KB)": 445,
+			},
+		},
+		{
+			name:   "disabled",
+			output: "Enabled: false\n",
+			want:   map[string]float64{"Enabled": 0},
+		},
+	}
+	for _, tt := range tests {
+		if got := parseLflowCacheStats(tt.output); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: parseLflowCacheStats() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseDpifShow(t *testing.T) {
+	output := `system@ovs-system: hit:14657 missed:1187
+  br-int:
+    br-int 65534/1: (internal)
+    tap1 3/4: (system)
+netdev@ovs-netdev: hit:10 missed:2 lost:1
+  br-ex:
+    br-ex 65534/1: (tap)
+`
+	want := map[string]map[string]float64{
+		"system@ovs-system": {"hit": 14657, "missed": 1187},
+		"netdev@ovs-netdev": {"hit": 10, "missed": 2, "lost": 1},
+	}
+	if got := parseDpifShow(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseDpifShow() = %v, want %v", got, want)
+	}
+}
+
+func TestParseUpcallShow(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   map[string]*upcallStats
+	}{
+		{
+			name: "kernel datapath",
+			output: `system@ovs-system:
+  flows         : (current 23) (avg 22) (max 120) (limit 200000)
+  offloaded flows : 0
+  dump duration : 2ms
+  ufid enabled : true
+
+  4: (keys 12)
+  5: (keys 11)
+`,
+			want: map[string]*upcallStats{
+				"system@ovs-system": {
+					flows:        map[string]float64{"current": 23, "avg": 22, "max": 120, "limit": 200000},
+					dumpDuration: 0.002,
+					handlerKeys:  map[string]float64{"4": 12, "5": 11},
+				},
+			},
+		},
+		{
+			name:   "no dump yet",
+			output: "netdev@ovs-netdev:\n  flows         : (current 0) (avg 0) (max 0) (limit 10000)\n",
+			want: map[string]*upcallStats{
+				"netdev@ovs-netdev": {
+					flows:        map[string]float64{"current": 0, "avg": 0, "max": 0, "limit": 10000},
+					dumpDuration: -1,
+					handlerKeys:  map[string]float64{},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		if got := parseUpcallShow(tt.output); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: parseUpcallShow() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
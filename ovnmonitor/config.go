@@ -1,61 +1,157 @@
 package ovnmonitor
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
+	"os"
+	"strings"
 
 	"github.com/spf13/pflag"
 )
 
+// Modes the exporter runs in. The central mode monitors the NB and SB
+// databases and ovn-northd, the controller mode the ovn-controller of a
+// hypervisor.
+const (
+	modeCentral    = "central"
+	modeController = "controller"
+)
+
 // Configuration contains parameters information.
 type Configuration struct {
-	ListenAddress                   string
-	MetricsPath                     string
-	PollTimeout                     int
-	PollInterval                    int
-	DatabaseNorthboundSocketRemote  string
-	DatabaseNorthboundSocketControl string
-	DatabaseNorthboundFileDataPath  string
-	DatabaseNorthboundFilePidPath   string
-	DatabaseNorthboundPortDefault   int
-	DatabaseNorthboundPortSsl       int
-	DatabaseNorthboundPortRaft      int
-	DatabaseSouthboundSocketRemote  string
-	DatabaseSouthboundSocketControl string
-	DatabaseSouthboundFileDataPath  string
-	DatabaseSouthboundFilePidPath   string
-	DatabaseSouthboundPortDefault   int
-	DatabaseSouthboundPortSsl       int
-	DatabaseSouthboundPortRaft      int
-	ServiceNorthdFilePidPath        string
-	ServiceNorthdSocketControl      string
+	Mode                              string
+	ListenAddress                     string
+	MetricsPath                       string
+	PollTimeout                       int
+	PollInterval                      int
+	DatabaseNorthboundSocketRemote    string
+	DatabaseLeaderOnly                bool
+	DatabaseNorthboundSocketControl   string
+	DatabaseNorthboundFileDataPath    string
+	DatabaseNorthboundFilePidPath     string
+	DatabaseNorthboundPortDefault     int
+	DatabaseNorthboundPortSsl         int
+	DatabaseNorthboundPortRaft        int
+	DatabaseSouthboundSocketRemote    string
+	DatabaseSouthboundSocketControl   string
+	DatabaseSouthboundFileDataPath    string
+	DatabaseSouthboundFilePidPath     string
+	DatabaseSouthboundPortDefault     int
+	DatabaseSouthboundPortSsl         int
+	DatabaseSouthboundPortRaft        int
+	DatabaseIcNorthboundSocketRemote  string
+	DatabaseIcNorthboundSocketControl string
+	DatabaseIcNorthboundFileDataPath  string
+	DatabaseIcNorthboundPortSsl       int
+	DatabaseIcSouthboundSocketRemote  string
+	DatabaseIcSouthboundSocketControl string
+	DatabaseIcSouthboundFileDataPath  string
+	DatabaseIcSouthboundPortSsl       int
+	ServiceNorthdFilePidPath          string
+	ServiceNorthdSocketControl        string
+	DatabaseVswitchSocketRemote       string
+	DatabaseVswitchPortSsl            int
+	ServiceControllerFilePidPath      string
+	ServiceControllerSocketControl    string
+	ServiceVswitchdFilePidPath        string
+	ServiceVswitchdSocketControl      string
+	SslPrivateKey                     string
+	SslCertificate                    string
+	SslCACert                         string
+	// Collectors holds for every collector whether it is enabled.
+	Collectors map[string]bool
+	// NatFipInfo exports an info series per floating IP.
+	NatFipInfo bool
+	// LogicalFlowTimeout is the timeout in seconds of reading the logical
+	// flows.
+	LogicalFlowTimeout int
+
+	tlsConfig *tls.Config
 }
 
 // ParseFlags get parameters information.
 func ParseFlags() (*Configuration, error) {
 	var (
+		argMode          = pflag.String("mode", modeCentral, "Components to monitor, central for the NB and SB databases and ovn-northd or controller for the local ovn-controller.")
 		argListenAddress = pflag.String("listen-address", ":10661", "Address to listen on for web interface and telemetry.")
 		argMetricsPath   = pflag.String("telemetry-path", "/metrics", "Path under which to expose metrics.")
 		argPollTimeout   = pflag.Int("ovs.timeout", 2, "Timeout on JSON-RPC requests to OVN.")
-		argPollInterval  = pflag.Int("ovs.poll-interval", 30, "The minimum interval (in seconds) between collections from OVN server.")
+		argPollInterval  = pflag.Int("ovs.poll-interval", 30, "The minimum interval (in seconds) between collections from OVN server, 0 collects on every scrape.")
 
-		argDatabaseNorthboundSocketRemote  = pflag.String("database.northbound.socket.remote", "unix:/run/ovn/ovnnb_db.sock", "JSON-RPC unix socket to OVN NB db.")
+		argDatabaseNorthboundSocketRemote  = pflag.String("database.northbound.socket.remote", "unix:/run/ovn/ovnnb_db.sock", "OVN NB db remote, one of unix:FILE, tcp:HOST:PORT or ssl:HOST[:PORT]. A comma-separated list connects to any member of a cluster.")
 		argDatabaseNorthboundSocketControl = pflag.String("database.northbound.socket.control", "/run/ovn/ovnnb_db.ctl", "control socket to OVN NB app.")
 		argDatabaseNorthboundFileDataPath  = pflag.String("database.northbound.file.data.path", "/etc/ovn/ovnnb_db.db", "OVN NB db file.")
+		argDatabaseNorthboundPortSsl       = pflag.Int("database.northbound.port.ssl", 6641, "OVN NB db port used for ssl remotes without a port.")
 
-		argDatabaseSouthboundSocketRemote  = pflag.String("database.southbound.socket.remote", "unix:/run/ovn/ovnsb_db.sock", "JSON-RPC unix socket to OVN SB db.")
+		argDatabaseSouthboundSocketRemote  = pflag.String("database.southbound.socket.remote", "unix:/run/ovn/ovnsb_db.sock", "OVN SB db remote, one of unix:FILE, tcp:HOST:PORT or ssl:HOST[:PORT]. A comma-separated list connects to any member of a cluster.")
 		argDatabaseSouthboundSocketControl = pflag.String("database.southbound.socket.control", "/run/ovn/ovnsb_db.ctl", "control socket to OVN SB app.")
 		argDatabaseSouthboundFileDataPath  = pflag.String("database.southbound.file.data.path", "/etc/ovn/ovnsb_db.db", "OVN SB db file.")
+		argDatabaseSouthboundPortSsl       = pflag.Int("database.southbound.port.ssl", 6642, "OVN SB db port used for ssl remotes without a port.")
+
+		argDatabaseIcNorthboundSocketRemote  = pflag.String("database.ic-northbound.socket.remote", "", "OVN IC NB db remote, one of unix:FILE, tcp:HOST:PORT or ssl:HOST[:PORT]. Empty if ovn-ic is not used.")
+		argDatabaseIcNorthboundSocketControl = pflag.String("database.ic-northbound.socket.control", "", "control socket to OVN IC NB app, e.g. /run/ovn/ovn_ic_nb_db.ctl. Empty if the IC NB db does not run on this host.")
+		argDatabaseIcNorthboundFileDataPath  = pflag.String("database.ic-northbound.file.data.path", "", "OVN IC NB db file, e.g. /etc/ovn/ovn_ic_nb_db.db. Empty if the IC NB db does not run on this host.")
+		argDatabaseIcNorthboundPortSsl       = pflag.Int("database.ic-northbound.port.ssl", 6645, "OVN IC NB db port used for ssl remotes without a port.")
+
+		argDatabaseIcSouthboundSocketRemote  = pflag.String("database.ic-southbound.socket.remote", "", "OVN IC SB db remote, one of unix:FILE, tcp:HOST:PORT or ssl:HOST[:PORT]. Empty if ovn-ic is not used.")
+		argDatabaseIcSouthboundSocketControl = pflag.String("database.ic-southbound.socket.control", "", "control socket to OVN IC SB app, e.g. /run/ovn/ovn_ic_sb_db.ctl. Empty if the IC SB db does not run on this host.")
+		argDatabaseIcSouthboundFileDataPath  = pflag.String("database.ic-southbound.file.data.path", "", "OVN IC SB db file, e.g. /etc/ovn/ovn_ic_sb_db.db. Empty if the IC SB db does not run on this host.")
+		argDatabaseIcSouthboundPortSsl       = pflag.Int("database.ic-southbound.port.ssl", 6646, "OVN IC SB db port used for ssl remotes without a port.")
+
+		argDatabaseLeaderOnly = pflag.Bool("database.leader-only", false, "Only read NB and SB db contents from the raft cluster leader.")
 
 		argServiceNorthdFilePidPath   = pflag.String("service.ovn.northd.file.pid.path", "/var/run/ovn/ovn-northd.pid", "OVN northd daemon process id file.")
 		argServiceNorthdSocketControl = pflag.String("service.ovn.northd.socket.control", "", "OVN northd control socket to northd app.")
+
+		argDatabaseVswitchSocketRemote    = pflag.String("database.vswitch.socket.remote", "unix:/run/openvswitch/db.sock", "Local Open_vSwitch db remote, used in controller mode.")
+		argDatabaseVswitchPortSsl         = pflag.Int("database.vswitch.port.ssl", 6640, "Local Open_vSwitch db port used for ssl remotes without a port.")
+		argServiceControllerFilePidPath   = pflag.String("service.ovn.controller.file.pid.path", "/var/run/ovn/ovn-controller.pid", "OVN controller daemon process id file.")
+		argServiceControllerSocketControl = pflag.String("service.ovn.controller.socket.control", "", "OVN controller control socket to ovn-controller app.")
+
+		argServiceVswitchdFilePidPath   = pflag.String("service.ovs.vswitchd.file.pid.path", "/var/run/openvswitch/ovs-vswitchd.pid", "OVS vswitchd daemon process id file, used by the ovs collector.")
+		argServiceVswitchdSocketControl = pflag.String("service.ovs.vswitchd.socket.control", "", "OVS vswitchd control socket to ovs-vswitchd app, used by the ovs collector.")
+
+		argSslPrivateKey  = pflag.String("ssl.private-key", "", "Private key file used to connect to ssl remotes.")
+		argSslCertificate = pflag.String("ssl.certificate", "", "Certificate file used to connect to ssl remotes.")
+		argSslCACert      = pflag.String("ssl.ca-cert", "", "CA certificate file used to verify ssl remotes.")
 	)
 
+	argNatFipInfo := pflag.Bool("collector.nat.fip-info", false, "Export an info series for every floating IP (dnat_and_snat rule).")
+	argLogicalFlowTimeout := pflag.Int("collector.logical-flow.timeout", 30, "Timeout on reading the logical flows, which takes longer than --ovs.timeout on large southbound databases.")
+
+	allCollectors := append(append([]ovnCollector{}, ovnCollectors...), controllerCollectors...)
+	argCollectors := make(map[string]*bool, len(allCollectors))
+	argNoCollectors := make(map[string]*bool, len(allCollectors))
+	// a collector only runs in the mode whose list it is in
+	for _, modeCollectors := range []struct {
+		mode       string
+		collectors []ovnCollector
+	}{{modeCentral, ovnCollectors}, {modeController, controllerCollectors}} {
+		for _, c := range modeCollectors.collectors {
+			argCollectors[c.name] = pflag.Bool("collector."+c.name, !c.disabled, fmt.Sprintf("Enable the %s collector (%s mode only), --no-collector.%s disables it.", c.name, modeCollectors.mode, c.name))
+			argNoCollectors[c.name] = pflag.Bool("no-collector."+c.name, false, fmt.Sprintf("Disable the %s collector.", c.name))
+			_ = pflag.CommandLine.MarkHidden("no-collector." + c.name)
+		}
+	}
+
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 	pflag.Parse()
 
+	if *argMode != modeCentral && *argMode != modeController {
+		return nil, fmt.Errorf("unknown mode %q, must be %s or %s", *argMode, modeCentral, modeController)
+	}
+
+	collectors := make(map[string]bool, len(allCollectors))
+	for _, c := range allCollectors {
+		collectors[c.name] = *argCollectors[c.name] && !*argNoCollectors[c.name]
+	}
+
 	config := &Configuration{
+		Mode:                            *argMode,
 		ListenAddress:                   *argListenAddress,
 		MetricsPath:                     *argMetricsPath,
 		PollTimeout:                     *argPollTimeout,
@@ -63,14 +159,97 @@ func ParseFlags() (*Configuration, error) {
 		DatabaseNorthboundSocketRemote:  *argDatabaseNorthboundSocketRemote,
 		DatabaseNorthboundSocketControl: *argDatabaseNorthboundSocketControl,
 		DatabaseNorthboundFileDataPath:  *argDatabaseNorthboundFileDataPath,
+		DatabaseNorthboundPortSsl:       *argDatabaseNorthboundPortSsl,
+
+		DatabaseLeaderOnly:                *argDatabaseLeaderOnly,
+		DatabaseSouthboundSocketRemote:    *argDatabaseSouthboundSocketRemote,
+		DatabaseSouthboundSocketControl:   *argDatabaseSouthboundSocketControl,
+		DatabaseSouthboundFileDataPath:    *argDatabaseSouthboundFileDataPath,
+		DatabaseSouthboundPortSsl:         *argDatabaseSouthboundPortSsl,
+		DatabaseIcNorthboundSocketRemote:  *argDatabaseIcNorthboundSocketRemote,
+		DatabaseIcNorthboundSocketControl: *argDatabaseIcNorthboundSocketControl,
+		DatabaseIcNorthboundFileDataPath:  *argDatabaseIcNorthboundFileDataPath,
+		DatabaseIcNorthboundPortSsl:       *argDatabaseIcNorthboundPortSsl,
+		DatabaseIcSouthboundSocketRemote:  *argDatabaseIcSouthboundSocketRemote,
+		DatabaseIcSouthboundSocketControl: *argDatabaseIcSouthboundSocketControl,
+		DatabaseIcSouthboundFileDataPath:  *argDatabaseIcSouthboundFileDataPath,
+		DatabaseIcSouthboundPortSsl:       *argDatabaseIcSouthboundPortSsl,
+		ServiceNorthdFilePidPath:          *argServiceNorthdFilePidPath,
+		ServiceNorthdSocketControl:        *argServiceNorthdSocketControl,
+		DatabaseVswitchSocketRemote:       *argDatabaseVswitchSocketRemote,
+		DatabaseVswitchPortSsl:            *argDatabaseVswitchPortSsl,
+		ServiceControllerFilePidPath:      *argServiceControllerFilePidPath,
+		ServiceControllerSocketControl:    *argServiceControllerSocketControl,
+		ServiceVswitchdFilePidPath:        *argServiceVswitchdFilePidPath,
+		ServiceVswitchdSocketControl:      *argServiceVswitchdSocketControl,
+		SslPrivateKey:                     *argSslPrivateKey,
+		SslCertificate:                    *argSslCertificate,
+		SslCACert:                         *argSslCACert,
+		Collectors:                        collectors,
+		NatFipInfo:                        *argNatFipInfo,
+		LogicalFlowTimeout:                *argLogicalFlowTimeout,
+	}
 
-		DatabaseSouthboundSocketRemote:  *argDatabaseSouthboundSocketRemote,
-		DatabaseSouthboundSocketControl: *argDatabaseSouthboundSocketControl,
-		DatabaseSouthboundFileDataPath:  *argDatabaseSouthboundFileDataPath,
-		ServiceNorthdFilePidPath:        *argServiceNorthdFilePidPath,
-		ServiceNorthdSocketControl:      *argServiceNorthdSocketControl,
+	if err := config.initTLS(); err != nil {
+		return nil, err
 	}
 
 	slog.Info(fmt.Sprintf("ovn monitor config is %+v", config))
 	return config, nil
 }
+
+// initTLS loads the ssl key, certificate and CA certificate. They are
+// required as soon as one of the remotes is an ssl remote.
+func (c *Configuration) initTLS() error {
+	sslRemote := false
+	remotes := strings.Join([]string{c.DatabaseNorthboundSocketRemote, c.DatabaseSouthboundSocketRemote,
+		c.DatabaseIcNorthboundSocketRemote, c.DatabaseIcSouthboundSocketRemote}, ",")
+	if c.Mode == modeController {
+		remotes = c.DatabaseVswitchSocketRemote
+	}
+	for _, remote := range splitRemotes(remotes) {
+		sslRemote = sslRemote || strings.HasPrefix(remote, "ssl:")
+	}
+	if !sslRemote && c.SslPrivateKey == "" && c.SslCertificate == "" && c.SslCACert == "" {
+		return nil
+	}
+	if c.SslPrivateKey == "" || c.SslCertificate == "" || c.SslCACert == "" {
+		return errors.New("ssl remotes require --ssl.private-key, --ssl.certificate and --ssl.ca-cert")
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.SslCertificate, c.SslPrivateKey)
+	if err != nil {
+		return fmt.Errorf("failed to load ssl certificate %s and private key %s: %w", c.SslCertificate, c.SslPrivateKey, err)
+	}
+	caCert, err := os.ReadFile(c.SslCACert)
+	if err != nil {
+		return fmt.Errorf("failed to read ssl CA certificate: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caCert) {
+		return fmt.Errorf("failed to parse ssl CA certificate %s: no PEM certificate found", c.SslCACert)
+	}
+
+	c.tlsConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		// Certificates issued by the OVS PKI do not name the host, so like
+		// the OVS tools only the chain is verified, not the server name.
+		InsecureSkipVerify: true, // #nosec G402
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("server presented no certificate")
+			}
+			opts := x509.VerifyOptions{
+				Roots:         roots,
+				Intermediates: x509.NewCertPool(),
+			}
+			for _, ic := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(ic)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		},
+	}
+	return nil
+}
@@ -1,37 +1,78 @@
 package ovnmonitor
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"sync"
 	"time"
 
-	"github.com/kubeovn/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 const metricNamespace = "ovn"
 
 var (
-	appName          = "ovn-exporter"
-	isClusterEnabled = true
-	tryConnectCnt    = 0
-	checkNbDbCnt     = 0
-	checkSbDbCnt     = 0
+	appName      = "ovn-exporter"
+	checkNbDbCnt = 0
+	checkSbDbCnt = 0
 )
 
 // Exporter collects OVN data from the given server and exports them using
 // the prometheus metrics package.
 type Exporter struct {
 	sync.RWMutex
-	Client              *ovsdb.OvnClient
+	mode                string
 	timeout             int
 	pollInterval        int
-	errors              int64
-	errorsLocker        sync.RWMutex
 	nbSocketControl     string
 	sbSocketControl     string
+	nbFileDataPath      string
+	sbFileDataPath      string
 	northdSocketControl string
+	northdFilePidPath   string
+	appctl              *unixctlClient
+	nbClient            *ovsdbClient
+	sbClient            *ovsdbClient
+	ovsClient           *ovsdbClient
+	// icnbClient and icsbClient are nil unless the IC databases are
+	// configured.
+	icnbClient         *ovsdbClient
+	icsbClient         *ovsdbClient
+	icnbSocketControl  string
+	icsbSocketControl  string
+	icnbFileDataPath   string
+	icsbFileDataPath   string
+	supervisors        []*connectionSupervisor
+	collectors         []ovnCollector
+	natFipInfo         bool
+	logicalFlowTimeout time.Duration
+
+	controllerSocketControl string
+	controllerFilePidPath   string
+	vswitchdSocketControl   string
+	vswitchdFilePidPath     string
+
+	// requestErrors counts the failed requests to the OVN stack.
+	requestErrors       map[requestErrorKey]float64
+	requestErrorsLocker sync.Mutex
+
+	// lastSuccess is the time of the last successful run of each collector.
+	lastSuccess map[string]time.Time
+
+	// clusterStatuses holds the cluster status of each database requested
+	// in the current collection cycle.
+	clusterStatuses map[string]clusterStatusResult
+
+	// sbRows holds the rows of the southbound tables several collectors
+	// read, selected once in the current collection cycle.
+	sbRows map[string]sbRowsResult
+
+	// metrics holds the result of the last collection; it is served to
+	// scrapes until it is older than pollInterval.
+	metrics        []prometheus.Metric
+	metricsUpdated time.Time
 }
 
 // OVNDBClusterStatus contains information about a cluster.
@@ -52,103 +93,314 @@ type OVNDBClusterStatus struct {
 	connOut         float64
 	connInErr       float64
 	connOutErr      float64
+	nextIndex       float64
+	matchIndex      float64
+	peers           []*OVNDBClusterPeer
+	// servers is the raw Servers section of cluster/status.
+	servers string
+}
+
+// clusterStatusResult is the outcome of a cluster/status request.
+type clusterStatusResult struct {
+	status *OVNDBClusterStatus
+	err    error
+}
+
+// sbRowsResult is the outcome of selecting a shared southbound table.
+type sbRowsResult struct {
+	rows []ovsdbRow
+	err  error
+}
+
+// OVNDBClusterPeer contains information about another server of a cluster,
+// as seen by the server that was queried.
+type OVNDBClusterPeer struct {
+	id         string
+	address    string
+	nextIndex  float64
+	matchIndex float64
+	// lastMsgAge is the time in seconds since the last message from the
+	// peer, it is negative when unknown.
+	lastMsgAge float64
+	inbound    bool
+	outbound   bool
 }
 
 // NewExporter returns an initialized Exporter.
 func NewExporter(cfg *Configuration) *Exporter {
-	e := Exporter{}
-	e.Client = ovsdb.NewOvnClient()
+	e := Exporter{
+		requestErrors:   make(map[requestErrorKey]float64),
+		lastSuccess:     make(map[string]time.Time),
+		clusterStatuses: make(map[string]clusterStatusResult),
+		sbRows:          make(map[string]sbRowsResult),
+	}
 	e.initParas(cfg)
 	return &e
 }
 
 func (e *Exporter) initParas(cfg *Configuration) {
+	e.mode = cfg.Mode
 	e.timeout = cfg.PollTimeout
 	e.pollInterval = cfg.PollInterval
 	e.nbSocketControl = cfg.DatabaseNorthboundSocketControl
 	e.sbSocketControl = cfg.DatabaseSouthboundSocketControl
+	e.appctl = newUnixctlClient(time.Duration(cfg.PollTimeout) * time.Second)
+	e.natFipInfo = cfg.NatFipInfo
+	e.logicalFlowTimeout = time.Duration(cfg.LogicalFlowTimeout) * time.Second
 
-	e.Client.Timeout = cfg.PollTimeout
+	collectors := ovnCollectors
+	if e.mode == modeController {
+		collectors = controllerCollectors
+	}
+	var enabled []string
+	for _, c := range collectors {
+		if cfg.Collectors[c.name] {
+			e.collectors = append(e.collectors, c)
+			enabled = append(enabled, c.name)
+		}
+	}
+	slog.Info("enabled collectors", "mode", e.mode, "collectors", enabled)
+
+	timeout := time.Duration(cfg.PollTimeout) * time.Second
+	e.nbClient = newOvsdbClient("OVN_Northbound", cfg.DatabaseNorthboundSocketRemote,
+		cfg.DatabaseLeaderOnly, cfg.DatabaseNorthboundPortSsl, cfg.tlsConfig, timeout)
+	e.nbFileDataPath = cfg.DatabaseNorthboundFileDataPath
+	e.sbClient = newOvsdbClient("OVN_Southbound", cfg.DatabaseSouthboundSocketRemote,
+		cfg.DatabaseLeaderOnly, cfg.DatabaseSouthboundPortSsl, cfg.tlsConfig, timeout)
+	e.sbFileDataPath = cfg.DatabaseSouthboundFileDataPath
+
+	if cfg.DatabaseIcNorthboundSocketRemote != "" {
+		e.icnbClient = newOvsdbClient("OVN_IC_Northbound", cfg.DatabaseIcNorthboundSocketRemote,
+			cfg.DatabaseLeaderOnly, cfg.DatabaseIcNorthboundPortSsl, cfg.tlsConfig, timeout)
+		e.icnbSocketControl = cfg.DatabaseIcNorthboundSocketControl
+		e.icnbFileDataPath = cfg.DatabaseIcNorthboundFileDataPath
+	}
+	if cfg.DatabaseIcSouthboundSocketRemote != "" {
+		e.icsbClient = newOvsdbClient("OVN_IC_Southbound", cfg.DatabaseIcSouthboundSocketRemote,
+			cfg.DatabaseLeaderOnly, cfg.DatabaseIcSouthboundPortSsl, cfg.tlsConfig, timeout)
+		e.icsbSocketControl = cfg.DatabaseIcSouthboundSocketControl
+		e.icsbFileDataPath = cfg.DatabaseIcSouthboundFileDataPath
+	}
 
-	e.Client.Database.Northbound.Name = "OVN_Northbound"
-	e.Client.Database.Northbound.Socket.Remote = cfg.DatabaseNorthboundSocketRemote
-	e.Client.Database.Northbound.Socket.Control = "unix:" + cfg.DatabaseNorthboundSocketControl
-	e.Client.Database.Northbound.File.Data.Path = cfg.DatabaseNorthboundFileDataPath
+	e.northdSocketControl = cfg.ServiceNorthdSocketControl
+	e.northdFilePidPath = cfg.ServiceNorthdFilePidPath
 
-	e.Client.Database.Southbound.Name = "OVN_Southbound"
-	e.Client.Database.Southbound.Socket.Remote = cfg.DatabaseSouthboundSocketRemote
-	e.Client.Database.Southbound.Socket.Control = "unix:" + cfg.DatabaseSouthboundSocketControl
-	e.Client.Database.Southbound.File.Data.Path = cfg.DatabaseSouthboundFileDataPath
+	e.ovsClient = newOvsdbClient("Open_vSwitch", cfg.DatabaseVswitchSocketRemote,
+		false, cfg.DatabaseVswitchPortSsl, cfg.tlsConfig, timeout)
+	e.controllerSocketControl = cfg.ServiceControllerSocketControl
+	e.controllerFilePidPath = cfg.ServiceControllerFilePidPath
+	e.vswitchdSocketControl = cfg.ServiceVswitchdSocketControl
+	e.vswitchdFilePidPath = cfg.ServiceVswitchdFilePidPath
+}
 
-	e.Client.Service.Northd.File.Pid.Path = cfg.ServiceNorthdFilePidPath
-	if cfg.ServiceNorthdSocketControl != "" {
-		e.northdSocketControl = cfg.ServiceNorthdSocketControl
-		e.Client.Service.Northd.Socket.Control = "unix" + cfg.ServiceNorthdSocketControl
+// StartSupervisor starts to supervise the database connections. The
+// connections are established in the background and re-established with
+// backoff whenever they are lost.
+func (e *Exporter) StartSupervisor() {
+	if e.mode == modeController {
+		if e.usesVswitchDatabase() {
+			e.supervisors = []*connectionSupervisor{newConnectionSupervisor(e.ovsClient)}
+		}
 	} else {
-		e.northdSocketControl = ""
+		e.supervisors = []*connectionSupervisor{
+			newConnectionSupervisor(e.nbClient),
+			newConnectionSupervisor(e.sbClient),
+		}
+		for _, client := range []*ovsdbClient{e.icnbClient, e.icsbClient} {
+			if client != nil {
+				e.supervisors = append(e.supervisors, newConnectionSupervisor(client))
+			}
+		}
+	}
+	for _, s := range e.supervisors {
+		go s.run()
 	}
 }
 
-// StartConnection connect to database socket
-func (e *Exporter) StartConnection() error {
-	if err := e.Client.Connect(); err != nil {
-		return err
+// collectorEnabled reports whether the collector name is enabled.
+func (e *Exporter) collectorEnabled(name string) bool {
+	for _, c := range e.collectors {
+		if c.name == name {
+			return true
+		}
 	}
-	slog.Info("Exporter connected successfully to database socket")
-	return nil
+	return false
 }
 
-// TryClientConnection try to connect to database socket after init exporter
-func (e *Exporter) TryClientConnection() {
-	for {
-		if tryConnectCnt > 5 {
-			slog.Error("ovn-exporter failed to reconnect to db socket")
-			os.Exit(1)
-		}
+// usesVswitchDatabase reports whether an enabled collector reads the local
+// Open_vSwitch database, only the controller and ovs collectors do.
+func (e *Exporter) usesVswitchDatabase() bool {
+	return e.collectorEnabled("controller") || e.collectorEnabled("ovs")
+}
 
-		if err := e.StartConnection(); err != nil {
-			tryConnectCnt++
-			slog.Error(fmt.Sprintf("ovn-exporter failed to reconnect db socket %v times", tryConnectCnt))
-		} else {
-			slog.Info("ovn-exporter reconnected to db successfully")
-			break
+// Healthy returns the names of the databases the exporter is currently not
+// connected to, it is empty when the exporter is healthy.
+func (e *Exporter) Healthy() []string {
+	var disconnected []string
+	for _, s := range e.supervisors {
+		if !s.connected.Load() {
+			disconnected = append(disconnected, s.client.database)
 		}
+	}
+	return disconnected
+}
+
+// ovsdbDatabase is a database whose ovsdb-server is monitored through its
+// control socket and database file.
+type ovsdbDatabase struct {
+	name   string
+	socket string
+	file   string
+}
 
-		time.Sleep(5 * time.Second)
+// databases returns the NB and SB databases followed by the IC databases
+// whose control socket and file are configured, the IC databases usually
+// run on other hosts.
+func (e *Exporter) databases() []ovsdbDatabase {
+	dbs := []ovsdbDatabase{
+		{name: e.nbClient.database, socket: e.nbSocketControl, file: e.nbFileDataPath},
+		{name: e.sbClient.database, socket: e.sbSocketControl, file: e.sbFileDataPath},
+	}
+	if e.icnbClient != nil && e.icnbSocketControl != "" && e.icnbFileDataPath != "" {
+		dbs = append(dbs, ovsdbDatabase{name: e.icnbClient.database, socket: e.icnbSocketControl, file: e.icnbFileDataPath})
 	}
+	if e.icsbClient != nil && e.icsbSocketControl != "" && e.icsbFileDataPath != "" {
+		dbs = append(dbs, ovsdbDatabase{name: e.icsbClient.database, socket: e.icsbSocketControl, file: e.icsbFileDataPath})
+	}
+	return dbs
 }
 
-var registerOvnMetricsOnce sync.Once
+// Describe implements prometheus.Collector.
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	describeOvnMetrics(ch)
+	ch <- metricDBConnected
+	ch <- metricDBReconnects
+	ch <- metricCollectorDuration
+	ch <- metricCollectorSuccess
+	ch <- metricCollectorLastSuccess
+	ch <- metricCollectionDuration
+}
 
-// StartOvnMetrics register and start to update ovn metrics
-func (e *Exporter) StartOvnMetrics() {
-	registerOvnMetricsOnce.Do(func() {
-		registerOvnMetrics()
+// Collect implements prometheus.Collector. The OVN stack is only queried when
+// the cached metrics are older than the poll interval, so concurrent or
+// frequent scrapes always see a complete and consistent set of series.
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range e.cachedMetrics() {
+		ch <- m
+	}
+	e.exportConnectionGauge(ch)
+}
 
-		// OVN metrics updater
-		go e.ovnMetricsUpdate()
-	})
+// exportConnectionGauge exports the state of the database connections. It
+// is never cached, so it always reflects the current state.
+func (e *Exporter) exportConnectionGauge(ch chan<- prometheus.Metric) {
+	for _, s := range e.supervisors {
+		connected := 0.0
+		if s.connected.Load() {
+			connected = 1
+		}
+		ch <- prometheus.MustNewConstMetric(metricDBConnected, prometheus.GaugeValue, connected, s.client.database)
+		ch <- prometheus.MustNewConstMetric(metricDBReconnects, prometheus.CounterValue, float64(s.reconnects.Load()), s.client.database)
+	}
 }
 
-// ovnMetricsUpdate updates the ovn metrics for every 30 sec
-func (e *Exporter) ovnMetricsUpdate() {
-	for {
-		e.exportOvnStatusGauge()
-		e.exportOvnDBFileSizeGauge()
-		e.exportOvnRequestErrorGauge()
-		e.exportOvnDBStatusGauge()
+func (e *Exporter) cachedMetrics() []prometheus.Metric {
+	e.Lock()
+	defer e.Unlock()
 
-		e.exportOvnChassisGauge()
-		e.exportLogicalSwitchGauge()
-		e.exportLogicalSwitchPortGauge()
+	if e.metrics != nil && time.Since(e.metricsUpdated) < time.Duration(e.pollInterval)*time.Second {
+		return e.metrics
+	}
 
-		e.exportOvnClusterEnableGauge()
-		if isClusterEnabled {
-			e.exportOvnClusterInfoGauge()
+	metricCh := make(chan prometheus.Metric)
+	done := make(chan struct{})
+	metrics := make([]prometheus.Metric, 0, len(e.metrics))
+	go func() {
+		for m := range metricCh {
+			metrics = append(metrics, m)
 		}
+		close(done)
+	}()
+	e.ovnMetricsUpdate(metricCh)
+	close(metricCh)
+	<-done
+
+	e.metrics = metrics
+	e.metricsUpdated = time.Now()
+	return metrics
+}
 
-		time.Sleep(time.Duration(e.pollInterval) * time.Second)
+// ovnCollector is a named part of a collection cycle, its duration and
+// outcome are exported so a slow or failing query can be told apart. Every
+// collector can be turned off with --no-collector.<name>, a collector that
+// is disabled by default has to be turned on with --collector.<name>.
+type ovnCollector struct {
+	name     string
+	collect  func(e *Exporter, ch chan<- prometheus.Metric) error
+	disabled bool
+}
+
+var ovnCollectors = []ovnCollector{
+	{name: "status", collect: (*Exporter).exportOvnStatusGauge},
+	{name: "db-file", collect: (*Exporter).exportOvnDBFileSizeGauge},
+	{name: "db-status", collect: (*Exporter).exportOvnDBStatusGauge},
+	{name: "chassis", collect: (*Exporter).exportOvnChassisGauge},
+	{name: "logical-switch", collect: (*Exporter).exportLogicalSwitchGauge},
+	{name: "logical-switch-port", collect: (*Exporter).exportLogicalSwitchPortGauge},
+	{name: "logical-router", collect: (*Exporter).exportLogicalRouterGauge},
+	{name: "logical-router-port", collect: (*Exporter).exportLogicalRouterPortGauge},
+	{name: "nat", collect: (*Exporter).exportNatGauge},
+	{name: "load-balancer", collect: (*Exporter).exportLoadBalancerGauge},
+	{name: "acl", collect: (*Exporter).exportACLGauge},
+	{name: "port-group", collect: (*Exporter).exportPortGroupGauge},
+	{name: "address-set", collect: (*Exporter).exportAddressSetGauge},
+	{name: "port-binding", collect: (*Exporter).exportPortBindingGauge},
+	{name: "mac-binding", collect: (*Exporter).exportMacBindingGauge},
+	{name: "fdb", collect: (*Exporter).exportFdbGauge},
+	{name: "global", collect: (*Exporter).exportGlobalGauge},
+	{name: "logical-flow", collect: (*Exporter).exportLogicalFlowGauge},
+	{name: "ic", collect: (*Exporter).exportICGauge},
+	{name: "ovsdb-server", collect: (*Exporter).exportDBServerGauge},
+	{name: "northd", collect: (*Exporter).exportNorthdGauge},
+	{name: "cluster", collect: (*Exporter).exportOvnClusterGauge},
+}
+
+// controllerCollectors are the collectors of the controller mode, they
+// query the local ovn-controller and Open_vSwitch database.
+var controllerCollectors = []ovnCollector{
+	{name: "controller", collect: (*Exporter).exportControllerGauge},
+	{name: "controller-stats", collect: (*Exporter).exportControllerStatsGauge},
+	{name: "ovs", collect: (*Exporter).exportOvsGauge, disabled: true},
+}
+
+// ovnMetricsUpdate collects the ovn metrics of all enabled collectors from
+// the OVN stack
+func (e *Exporter) ovnMetricsUpdate(ch chan<- prometheus.Metric) {
+	cycleStart := time.Now()
+	e.clusterStatuses = make(map[string]clusterStatusResult)
+	e.sbRows = make(map[string]sbRowsResult)
+	for _, c := range e.collectors {
+		start := time.Now()
+		err := c.collect(e, ch)
+		duration := time.Since(start)
+
+		success := 0.0
+		if err != nil {
+			slog.Error("collector failed", "collector", c.name, "duration", duration, "error", err)
+		} else {
+			success = 1
+			e.lastSuccess[c.name] = time.Now()
+		}
+		lastSuccess := 0.0
+		if t, ok := e.lastSuccess[c.name]; ok {
+			lastSuccess = float64(t.UnixNano()) / 1e9
+		}
+		ch <- prometheus.MustNewConstMetric(metricCollectorDuration, prometheus.GaugeValue, duration.Seconds(), c.name)
+		ch <- prometheus.MustNewConstMetric(metricCollectorSuccess, prometheus.GaugeValue, success, c.name)
+		ch <- prometheus.MustNewConstMetric(metricCollectorLastSuccess, prometheus.GaugeValue, lastSuccess, c.name)
 	}
+	e.exportOvnRequestErrorCounter(ch)
+	ch <- prometheus.MustNewConstMetric(metricCollectionDuration, prometheus.GaugeValue, time.Since(cycleStart).Seconds())
 }
 
 // GetExporterName returns exporter name.
@@ -156,128 +408,196 @@ func GetExporterName() string {
 	return appName
 }
 
-func (e *Exporter) exportOvnStatusGauge() {
-	metricOvnHealthyStatus.Reset()
+func (e *Exporter) exportOvnStatusGauge(ch chan<- prometheus.Metric) error {
 	result := e.getOvnStatus()
 	for k, v := range result {
-		metricOvnHealthyStatus.WithLabelValues(k).Set(float64(v))
+		ch <- prometheus.MustNewConstMetric(metricOvnHealthyStatus, prometheus.GaugeValue, float64(v), k)
 	}
 
-	metricOvnHealthyStatusContent.Reset()
 	statusResult := e.getOvnStatusContent()
 	for k, v := range statusResult {
-		metricOvnHealthyStatusContent.WithLabelValues(k, v).Set(float64(1))
+		ch <- prometheus.MustNewConstMetric(metricOvnHealthyStatusContent, prometheus.GaugeValue, 1, k, v)
 	}
+	return nil
 }
 
-func (e *Exporter) exportOvnDBFileSizeGauge() {
-	metricDBFileSize.Reset()
-	nbPath := e.Client.Database.Northbound.File.Data.Path
-	sbPath := e.Client.Database.Southbound.File.Data.Path
-	dirDbMap := map[string]string{
-		nbPath: "OVN_Northbound",
-		sbPath: "OVN_Southbound",
-	}
-	for dbFile, database := range dirDbMap {
-		fileInfo, err := os.Stat(dbFile)
+func (e *Exporter) exportOvnDBFileSizeGauge(ch chan<- prometheus.Metric) error {
+	var errs []error
+	for _, db := range e.databases() {
+		fileInfo, err := os.Stat(db.file)
 		if err != nil {
-			slog.Error(fmt.Sprintf("Failed to get the DB size for database %s", database), "error", err)
-			return
+			errs = append(errs, fmt.Errorf("failed to get the DB size for database %s: %w", db.name, err))
+			continue
 		}
-		metricDBFileSize.WithLabelValues(database).Set(float64(fileInfo.Size()))
+		ch <- prometheus.MustNewConstMetric(metricDBFileSize, prometheus.GaugeValue, float64(fileInfo.Size()), db.name)
 	}
+	return errors.Join(errs...)
 }
 
-func (e *Exporter) exportOvnRequestErrorGauge() {
-	metricRequestErrorNums.WithLabelValues().Set(float64(e.errors))
+func (e *Exporter) exportOvnRequestErrorCounter(ch chan<- prometheus.Metric) {
+	e.requestErrorsLocker.Lock()
+	defer e.requestErrorsLocker.Unlock()
+	for k, v := range e.requestErrors {
+		ch <- prometheus.MustNewConstMetric(metricRequestErrorNums, prometheus.CounterValue, v, k.database, k.operation, k.class)
+	}
 }
 
-func (e *Exporter) exportOvnChassisGauge() {
-	metricChassisInfo.Reset()
-	if vteps, err := e.Client.GetChassis(); err != nil {
-		slog.Error(fmt.Sprintf("%s", e.Client.Database.Southbound.Name), "error", err)
-		e.IncrementErrorCounter()
-	} else {
-		for _, vtep := range vteps {
-			metricChassisInfo.WithLabelValues(vtep.Hostname, vtep.UUID, vtep.Name, vtep.IPAddress.String()).Set(1)
-		}
+func (e *Exporter) exportOvnChassisGauge(ch chan<- prometheus.Metric) error {
+	rows, err := e.selectChassisRows()
+	if err != nil {
+		return err
 	}
+	vteps := e.getChassis(rows)
+	for _, vtep := range vteps {
+		ch <- prometheus.MustNewConstMetric(metricChassisInfo, prometheus.GaugeValue, 1,
+			vtep.Hostname, vtep.UUID, vtep.Name, vtep.IPAddress.String())
+	}
+	return e.setChassisHealthMetric(ch, rows)
 }
 
-func (e *Exporter) exportLogicalSwitchGauge() {
-	resetLogicalSwitchMetrics()
-	e.setLogicalSwitchInfoMetric()
+func (e *Exporter) exportLogicalSwitchGauge(ch chan<- prometheus.Metric) error {
+	return e.setLogicalSwitchInfoMetric(ch)
 }
 
-func (e *Exporter) exportLogicalSwitchPortGauge() {
-	resetLogicalSwitchPortMetrics()
-	e.setLogicalSwitchPortInfoMetric()
+func (e *Exporter) exportLogicalSwitchPortGauge(ch chan<- prometheus.Metric) error {
+	return e.setLogicalSwitchPortInfoMetric(ch)
 }
 
-func (e *Exporter) exportOvnClusterEnableGauge() {
-	metricClusterEnabled.Reset()
-	isClusterEnabled, err := getClusterEnableState(e.Client.Database.Northbound.File.Data.Path)
-	if err != nil {
-		slog.Error("failed to get output of cluster status", "error", err)
-	}
-	if isClusterEnabled {
-		metricClusterEnabled.WithLabelValues(e.Client.Database.Northbound.File.Data.Path).Set(1)
-	} else {
-		metricClusterEnabled.WithLabelValues(e.Client.Database.Northbound.File.Data.Path).Set(0)
-	}
+func (e *Exporter) exportLogicalRouterGauge(ch chan<- prometheus.Metric) error {
+	return e.setLogicalRouterInfoMetric(ch)
+}
+
+func (e *Exporter) exportLogicalRouterPortGauge(ch chan<- prometheus.Metric) error {
+	return e.setLogicalRouterPortInfoMetric(ch)
+}
+
+func (e *Exporter) exportNatGauge(ch chan<- prometheus.Metric) error {
+	return e.setNatInfoMetric(ch)
+}
+
+func (e *Exporter) exportLoadBalancerGauge(ch chan<- prometheus.Metric) error {
+	return e.setLoadBalancerInfoMetric(ch)
+}
+
+func (e *Exporter) exportACLGauge(ch chan<- prometheus.Metric) error {
+	return e.setACLInfoMetric(ch)
+}
+
+func (e *Exporter) exportPortGroupGauge(ch chan<- prometheus.Metric) error {
+	return e.setPortGroupInfoMetric(ch)
+}
+
+func (e *Exporter) exportAddressSetGauge(ch chan<- prometheus.Metric) error {
+	return e.setAddressSetInfoMetric(ch)
+}
+
+func (e *Exporter) exportPortBindingGauge(ch chan<- prometheus.Metric) error {
+	return e.setPortBindingInfoMetric(ch)
+}
+
+func (e *Exporter) exportMacBindingGauge(ch chan<- prometheus.Metric) error {
+	return e.setMacBindingInfoMetric(ch)
 }
 
-func (e *Exporter) exportOvnClusterInfoGauge() {
-	resetOvnClusterMetrics()
-	dirDbMap := map[string]string{
-		e.nbSocketControl: "OVN_Northbound",
-		e.sbSocketControl: "OVN_Southbound",
+func (e *Exporter) exportFdbGauge(ch chan<- prometheus.Metric) error {
+	return e.setFdbInfoMetric(ch)
+}
+
+func (e *Exporter) exportGlobalGauge(ch chan<- prometheus.Metric) error {
+	return e.setGlobalInfoMetric(ch)
+}
+
+func (e *Exporter) exportLogicalFlowGauge(ch chan<- prometheus.Metric) error {
+	return e.setLogicalFlowInfoMetric(ch)
+}
+
+func (e *Exporter) exportICGauge(ch chan<- prometheus.Metric) error {
+	return e.setICInfoMetric(ch)
+}
+
+func (e *Exporter) exportDBServerGauge(ch chan<- prometheus.Metric) error {
+	var errs []error
+	for _, db := range e.databases() {
+		errs = append(errs, e.setDBServerStatsMetric(ch, db.socket, db.name))
 	}
-	for socket, database := range dirDbMap {
-		clusterStatus, err := getClusterInfo(socket, database)
+	return errors.Join(errs...)
+}
+
+func (e *Exporter) exportNorthdGauge(ch chan<- prometheus.Metric) error {
+	return e.setNorthdStatsMetric(ch)
+}
+
+func (e *Exporter) exportControllerGauge(ch chan<- prometheus.Metric) error {
+	return e.setControllerInfoMetric(ch)
+}
+
+func (e *Exporter) exportControllerStatsGauge(ch chan<- prometheus.Metric) error {
+	return e.setControllerStatsMetric(ch)
+}
+
+func (e *Exporter) exportOvsGauge(ch chan<- prometheus.Metric) error {
+	return e.setOvsInfoMetric(ch)
+}
+
+// exportOvnClusterGauge exports whether every database is clustered along
+// with the raft state of the clustered ones. A database that fails does not
+// keep the others from being collected.
+func (e *Exporter) exportOvnClusterGauge(ch chan<- prometheus.Metric) error {
+	var errs []error
+	for _, db := range e.databases() {
+		clustered, err := getClusterEnableState(db.file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get cluster state of database %s: %w", db.name, err))
+			continue
+		}
+		if !clustered {
+			ch <- prometheus.MustNewConstMetric(metricClusterEnabled, prometheus.GaugeValue, 0, db.file)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(metricClusterEnabled, prometheus.GaugeValue, 1, db.file)
+		clusterStatus, err := e.getClusterInfo(db.socket, db.name)
 		if err != nil {
-			slog.Error(fmt.Sprintf("Failed to get Cluster Info for database %s", database), "error", err)
-			return
+			errs = append(errs, fmt.Errorf("failed to get Cluster Info for database %s: %w", db.name, err))
+			continue
 		}
-		e.setOvnClusterInfoMetric(clusterStatus, database)
+		e.setOvnClusterInfoMetric(ch, clusterStatus, db.name)
 	}
+	return errors.Join(errs...)
 }
 
-func (e *Exporter) exportOvnDBStatusGauge() {
-	metricDBStatus.Reset()
-	dbMap := map[string]string{
-		e.nbSocketControl: "OVN_Northbound",
-		e.sbSocketControl: "OVN_Southbound",
-	}
-	for socket, database := range dbMap {
-		ok, err := getDBStatus(socket, database)
+func (e *Exporter) exportOvnDBStatusGauge(ch chan<- prometheus.Metric) error {
+	var errs []error
+	for _, db := range e.databases() {
+		database := db.name
+		ok, err := e.getDBStatus(db.socket, database)
 		if err != nil {
-			slog.Error(fmt.Sprintf("Failed to get DB status for %s", database), "error", err)
-			return
+			errs = append(errs, fmt.Errorf("failed to get DB status for %s: %w", database, err))
+			continue
 		}
 		if ok {
-			metricDBStatus.WithLabelValues(database).Set(1)
-		} else {
-			metricDBStatus.WithLabelValues(database).Set(0)
-
-			switch database {
-			case "OVN_Northbound":
-				checkNbDbCnt++
-				if checkNbDbCnt < 6 {
-					slog.Warn(fmt.Sprintf("Failed to get OVN NB DB status for %v times", checkNbDbCnt))
-					return
-				}
-				slog.Warn(fmt.Sprintf("Failed to get OVN NB DB status for %v times, ready to restore OVN DB", checkNbDbCnt))
-				checkNbDbCnt = 0
-			case "OVN_Southbound":
-				checkSbDbCnt++
-				if checkSbDbCnt < 6 {
-					slog.Warn(fmt.Sprintf("Failed to get OVN SB DB status for %v times", checkSbDbCnt))
-					return
-				}
-				slog.Warn(fmt.Sprintf("Failed to get OVN SB DB status for %v times, ready to restore OVN DB", checkSbDbCnt))
-				checkSbDbCnt = 0
+			ch <- prometheus.MustNewConstMetric(metricDBStatus, prometheus.GaugeValue, 1, database)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(metricDBStatus, prometheus.GaugeValue, 0, database)
+
+		switch database {
+		case "OVN_Northbound":
+			checkNbDbCnt++
+			if checkNbDbCnt < 6 {
+				slog.Warn(fmt.Sprintf("Failed to get OVN NB DB status for %v times", checkNbDbCnt))
+				continue
+			}
+			slog.Warn(fmt.Sprintf("Failed to get OVN NB DB status for %v times, ready to restore OVN DB", checkNbDbCnt))
+			checkNbDbCnt = 0
+		case "OVN_Southbound":
+			checkSbDbCnt++
+			if checkSbDbCnt < 6 {
+				slog.Warn(fmt.Sprintf("Failed to get OVN SB DB status for %v times", checkSbDbCnt))
+				continue
 			}
+			slog.Warn(fmt.Sprintf("Failed to get OVN SB DB status for %v times, ready to restore OVN DB", checkSbDbCnt))
+			checkSbDbCnt = 0
 		}
 	}
+	return errors.Join(errs...)
 }
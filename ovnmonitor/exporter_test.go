@@ -0,0 +1,76 @@
+package ovnmonitor
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// newTestExporter returns an exporter reading the northbound and southbound
+// database from the given clients, either may be nil.
+func newTestExporter(nbClient, sbClient *ovsdbClient) *Exporter {
+	return &Exporter{
+		nbClient:      nbClient,
+		sbClient:      sbClient,
+		requestErrors: make(map[requestErrorKey]float64),
+		sbRows:        make(map[string]sbRowsResult),
+	}
+}
+
+// collectMetrics runs a step of a collector and returns the values of the
+// metrics of desc it exported, keyed by their labels in the form
+// name=value,... sorted by name.
+func collectMetrics(t *testing.T, set func(ch chan<- prometheus.Metric) error, desc *prometheus.Desc) map[string]float64 {
+	t.Helper()
+	ch := make(chan prometheus.Metric)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- set(ch)
+		close(ch)
+	}()
+
+	values := make(map[string]float64)
+	for metric := range ch {
+		if metric.Desc() != desc {
+			continue
+		}
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			t.Fatalf("invalid metric %s: %v", metric.Desc(), err)
+		}
+		labels := make([]string, 0, len(m.GetLabel()))
+		for _, l := range m.GetLabel() {
+			labels = append(labels, l.GetName()+"="+l.GetValue())
+		}
+		sort.Strings(labels)
+		values[strings.Join(labels, ",")] = m.GetGauge().GetValue() + m.GetCounter().GetValue() + m.GetUntyped().GetValue()
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("collecting %s failed: %v", desc, err)
+	}
+	return values
+}
+
+func TestDatabasesSkipsRemoteIC(t *testing.T) {
+	e := &Exporter{
+		nbClient:          &ovsdbClient{database: "OVN_Northbound"},
+		sbClient:          &ovsdbClient{database: "OVN_Southbound"},
+		icnbClient:        &ovsdbClient{database: "OVN_IC_Northbound"},
+		icsbClient:        &ovsdbClient{database: "OVN_IC_Southbound"},
+		icsbSocketControl: "/run/ovn/ovn_ic_sb_db.ctl",
+		icsbFileDataPath:  "/etc/ovn/ovn_ic_sb_db.db",
+	}
+
+	// the IC NB database is only reachable through its remote
+	var got []string
+	for _, db := range e.databases() {
+		got = append(got, db.name)
+	}
+	want := []string{"OVN_Northbound", "OVN_Southbound", "OVN_IC_Southbound"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("databases() = %v, want %v", got, want)
+	}
+}
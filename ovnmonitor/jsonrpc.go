@@ -0,0 +1,112 @@
+package ovnmonitor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+)
+
+// jsonrpcRequest is a JSON-RPC 1.0 request or notification as spoken by the
+// OVS daemons (RFC 7047, section 4).
+type jsonrpcRequest struct {
+	Method string `json:"method"`
+	Params []any  `json:"params"`
+	ID     any    `json:"id"`
+}
+
+// jsonrpcResponse is a JSON-RPC 1.0 response to a jsonrpcRequest.
+type jsonrpcResponse struct {
+	Result any `json:"result"`
+	Error  any `json:"error"`
+	ID     any `json:"id"`
+}
+
+// jsonrpcMessage is any message received from the server. Requests sent by
+// the server (e.g. "echo") carry a method, responses carry a result or error.
+type jsonrpcMessage struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  json.RawMessage `json:"error"`
+	ID     json.RawMessage `json:"id"`
+}
+
+// jsonrpcError is the error member of a JSON-RPC response.
+type jsonrpcError struct {
+	Message string
+}
+
+func (e *jsonrpcError) Error() string {
+	return e.Message
+}
+
+// jsonrpcConn is a synchronous JSON-RPC 1.0 connection. It is not safe for
+// concurrent use.
+type jsonrpcConn struct {
+	conn   net.Conn
+	enc    *json.Encoder
+	dec    *json.Decoder
+	nextID uint64
+}
+
+func newJSONRPCConn(conn net.Conn) *jsonrpcConn {
+	return &jsonrpcConn{
+		conn: conn,
+		enc:  json.NewEncoder(conn),
+		dec:  json.NewDecoder(conn),
+	}
+}
+
+// call sends method with params and decodes the result of the matching
+// response into result. Echo requests from the server are answered while
+// waiting for the response.
+func (c *jsonrpcConn) call(method string, params []any, result any) error {
+	if params == nil {
+		params = []any{}
+	}
+	id := c.nextID
+	c.nextID++
+	if err := c.enc.Encode(jsonrpcRequest{Method: method, Params: params, ID: id}); err != nil {
+		return err
+	}
+
+	wantID, _ := json.Marshal(id)
+	for {
+		var msg jsonrpcMessage
+		if err := c.dec.Decode(&msg); err != nil {
+			return err
+		}
+		if msg.Method != "" {
+			if msg.Method == "echo" {
+				var echo []any
+				_ = json.Unmarshal(msg.Params, &echo)
+				if err := c.enc.Encode(jsonrpcResponse{Result: echo, ID: msg.ID}); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !bytes.Equal(msg.ID, wantID) {
+			continue
+		}
+		if len(msg.Error) != 0 && !bytes.Equal(msg.Error, []byte("null")) {
+			var message string
+			if err := json.Unmarshal(msg.Error, &message); err != nil {
+				message = string(msg.Error)
+			}
+			return &jsonrpcError{Message: message}
+		}
+		if result == nil {
+			return nil
+		}
+		if err := json.Unmarshal(msg.Result, result); err != nil {
+			return fmt.Errorf("failed to decode %s reply: %w", method, err)
+		}
+		return nil
+	}
+}
+
+func (c *jsonrpcConn) Close() error {
+	return c.conn.Close()
+}
@@ -4,124 +4,155 @@ import "github.com/prometheus/client_golang/prometheus"
 
 var (
 	// OVN basic info
-	metricOvnHealthyStatus = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "status",
-			Help:      "OVN Health Status. The values are:(3) for active or leader, (2) for candidate, (1) for standby or follower, (0) for unhealthy.",
-		},
+	metricOvnHealthyStatus = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "status"),
+		"OVN Health Status. The values are:(3) for active or leader, (2) for candidate, (1) for standby or follower, (0) for unhealthy.",
 		[]string{
 			"component",
-		})
+		}, nil)
 
-	metricOvnHealthyStatusContent = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "status_content",
-			Help:      "OVN Health Status. The values are always 1. While the value of status label is the really status report.",
-		},
+	metricOvnHealthyStatusContent = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "status_content"),
+		"OVN Health Status. The values are always 1. While the value of status label is the really status report.",
 		[]string{
 			"component",
 			"status",
-		})
+		}, nil)
 
-	metricRequestErrorNums = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "failed_req_count",
-			Help:      "The number of failed requests to OVN stack.",
-		},
+	metricRequestErrorNums = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "failed_req_count"),
+		"The number of failed requests to OVN stack by database, operation and error class. The database is empty for requests to ovn-northd.",
 		[]string{
-		})
+			"db_name",
+			"operation",
+			"error_class",
+		}, nil)
 
-	metricDBFileSize = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "db_file_size_bytes",
-			Help:      "The size of a database file associated with an OVN component. The unit is Bytes.",
-		},
+	metricDBFileSize = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "db_file_size_bytes"),
+		"The size of a database file associated with an OVN component. The unit is Bytes.",
 		[]string{
 			"db_name",
-		})
+		}, nil)
 
 	// OVN Chassis metrics
-	metricChassisInfo = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "chassis_info",
-			Help:      "The information about the chassis. This metric is always up (1).",
-		},
+	metricChassisInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "chassis_info"),
+		"The information about the chassis. This metric is always up (1).",
 		[]string{
 			"hostname",
 			"uuid",
 			"name",
 			"ip",
-		})
+		}, nil)
+
+	metricChassisEncapInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "chassis_encap_info"),
+		"The information about a tunnel endpoint of the chassis. This metric is always up (1).",
+		[]string{
+			"uuid",
+			"name",
+			"type",
+			"ip",
+		}, nil)
+
+	metricChassisEncapsNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "chassis_encaps_num"),
+		"The number of tunnel endpoints of the chassis by encapsulation type.",
+		[]string{
+			"uuid",
+			"name",
+			"type",
+		}, nil)
+
+	metricChassisNbCfg = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "chassis_nb_cfg"),
+		"The northbound configuration sequence number the chassis caught up with, from Chassis_Private.",
+		[]string{
+			"uuid",
+			"name",
+		}, nil)
+
+	metricChassisNbCfgLag = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "chassis_nb_cfg_lag"),
+		"The number of northbound configuration sequence numbers the chassis is behind NB_Global.nb_cfg.",
+		[]string{
+			"uuid",
+			"name",
+		}, nil)
+
+	metricChassisNbCfgTimestampAge = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "chassis_nb_cfg_timestamp_age_seconds"),
+		"The time in seconds since the chassis last caught up with the northbound configuration.",
+		[]string{
+			"uuid",
+			"name",
+		}, nil)
+
+	metricChassisPortBindingsNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "chassis_port_bindings_num"),
+		"The number of port bindings claimed by the chassis.",
+		[]string{
+			"uuid",
+			"name",
+		}, nil)
+
+	metricChassisOtherConfig = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "chassis_other_config"),
+		"Provides selected other_config keys and values of the chassis, such as ovn-cms-options. This metric is always up (1).",
+		[]string{
+			"uuid",
+			"name",
+			"key",
+			"value",
+		}, nil)
 
-	metricLogicalSwitchInfo = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "logical_switch_info",
-			Help:      "The information about OVN logical switch. This metric is always up (1).",
-		},
+	metricLogicalSwitchInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_switch_info"),
+		"The information about OVN logical switch. This metric is always up (1).",
 		[]string{
 			"uuid",
 			"name",
-		})
+		}, nil)
 
-	metricLogicalSwitchExternalIDs = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "logical_switch_external_id",
-			Help:      "Provides the external IDs and values associated with OVN logical switches. This metric is always up (1).",
-		},
+	metricLogicalSwitchExternalIDs = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_switch_external_id"),
+		"Provides the external IDs and values associated with OVN logical switches. This metric is always up (1).",
 		[]string{
 			"uuid",
 			"key",
 			"value",
 			"logical_switch_name",
-		})
+		}, nil)
 
-	metricLogicalSwitchPortBinding = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "logical_switch_port_binding",
-			Help:      "Provides the association between a logical switch and a logical switch port. This metric is always up (1).",
-		},
+	metricLogicalSwitchPortBinding = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_switch_port_binding"),
+		"Provides the association between a logical switch and a logical switch port. This metric is always up (1).",
 		[]string{
 			"uuid",
 			"port",
 			"logical_switch_name",
-		})
+		}, nil)
 
-	metricLogicalSwitchTunnelKey = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "logical_switch_tunnel_key",
-			Help:      "The value of the tunnel key associated with the logical switch.",
-		},
+	metricLogicalSwitchTunnelKey = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_switch_tunnel_key"),
+		"The value of the tunnel key associated with the logical switch.",
 		[]string{
 			"uuid",
 			"logical_switch_name",
-		})
+		}, nil)
 
-	metricLogicalSwitchPortsNum = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "logical_switch_ports_num",
-			Help:      "The number of logical switch ports connected to the OVN logical switch.",
-		},
+	metricLogicalSwitchPortsNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_switch_ports_num"),
+		"The number of logical switch ports connected to the OVN logical switch.",
 		[]string{
 			"uuid",
 			"logical_switch_name",
-		})
+		}, nil)
 
-	metricLogicalSwitchPortInfo = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "logical_switch_port_info",
-			Help:      "The information about OVN logical switch port. This metric is always up (1).",
-		},
+	metricLogicalSwitchPortInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_switch_port_info"),
+		"The information about OVN logical switch port. This metric is always up (1).",
 		[]string{
 			"uuid",
 			"name",
@@ -131,346 +162,1007 @@ var (
 			"port_binding",
 			"mac_address",
 			"ip_address",
-		})
+		}, nil)
 
-	metricLogicalSwitchPortTunnelKey = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "logical_switch_port_tunnel_key",
-			Help:      "The value of the tunnel key associated with the logical switch port.",
-		},
+	metricLogicalSwitchPortTunnelKey = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_switch_port_tunnel_key"),
+		"The value of the tunnel key associated with the logical switch port.",
 		[]string{
 			"uuid",
 			"logical_switch_name",
 			"port_name",
-		})
+		}, nil)
+
+	// OVN logical router metrics
+	metricLogicalRouterInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_router_info"),
+		"The information about OVN logical router. This metric is always up (1).",
+		[]string{
+			"uuid",
+			"name",
+		}, nil)
+
+	metricLogicalRouterPortsNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_router_ports_num"),
+		"The number of logical router ports of the OVN logical router.",
+		[]string{
+			"uuid",
+			"logical_router_name",
+		}, nil)
+
+	metricLogicalRouterStaticRoutesNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_router_static_routes_num"),
+		"The number of static routes of the OVN logical router.",
+		[]string{
+			"uuid",
+			"logical_router_name",
+		}, nil)
+
+	metricLogicalRouterPoliciesNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_router_policies_num"),
+		"The number of routing policies of the OVN logical router.",
+		[]string{
+			"uuid",
+			"logical_router_name",
+		}, nil)
+
+	metricLogicalRouterNatRulesNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_router_nat_rules_num"),
+		"The number of NAT rules of the OVN logical router.",
+		[]string{
+			"uuid",
+			"logical_router_name",
+		}, nil)
+
+	metricLogicalRouterLoadBalancersNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_router_load_balancers_num"),
+		"The number of load balancers applied to the OVN logical router.",
+		[]string{
+			"uuid",
+			"logical_router_name",
+		}, nil)
+
+	metricLogicalRouterPortInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_router_port_info"),
+		"The information about OVN logical router port. This metric is always up (1).",
+		[]string{
+			"uuid",
+			"name",
+			"logical_router_name",
+			"mac_address",
+			"networks",
+			"peer",
+		}, nil)
+
+	metricLogicalRouterPortGatewayChassisPriority = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_router_port_gateway_chassis_priority"),
+		"The priority of a chassis that may host the distributed gateway port, from Gateway_Chassis or HA_Chassis_Group.",
+		[]string{
+			"uuid",
+			"port_name",
+			"logical_router_name",
+			"chassis_name",
+		}, nil)
+
+	metricLogicalRouterPortGatewayChassisActive = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_router_port_gateway_chassis_active"),
+		"Is the chassis currently hosting the distributed gateway port (1) or not (0), according to the southbound Port_Binding.",
+		[]string{
+			"uuid",
+			"port_name",
+			"logical_router_name",
+			"chassis_name",
+		}, nil)
+
+	metricLogicalRouterPortGatewayActive = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_router_port_gateway_active"),
+		"Is the distributed gateway port hosted by any chassis (1) or not (0).",
+		[]string{
+			"uuid",
+			"port_name",
+			"logical_router_name",
+		}, nil)
+
+	// OVN NAT metrics
+	metricLogicalRouterNatTypeNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_router_nat_type_num"),
+		"The number of NAT rules of the OVN logical router by type (snat, dnat, dnat_and_snat).",
+		[]string{
+			"uuid",
+			"logical_router_name",
+			"type",
+		}, nil)
+
+	metricNatDuplicateExternalIP = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "nat_duplicate_external_ip"),
+		"Flags a dnat or dnat_and_snat rule whose external IP is used by another such rule. This metric is always up (1).",
+		[]string{
+			"uuid",
+			"logical_router_name",
+			"type",
+			"external_ip",
+			"logical_ip",
+		}, nil)
+
+	metricNatFipInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "nat_fip_info"),
+		"The information about a floating IP (dnat_and_snat rule), exported with --collector.nat.fip-info. This metric is always up (1).",
+		[]string{
+			"uuid",
+			"logical_router_name",
+			"logical_ip",
+			"external_ip",
+			"external_mac",
+			"logical_port",
+		}, nil)
+
+	// OVN load balancer metrics
+	metricLoadBalancerInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "load_balancer_info"),
+		"The information about OVN load balancer. This metric is always up (1).",
+		[]string{
+			"uuid",
+			"name",
+			"protocol",
+		}, nil)
+
+	metricLoadBalancerVipsNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "load_balancer_vips_num"),
+		"The number of VIPs of the OVN load balancer.",
+		[]string{
+			"uuid",
+			"load_balancer_name",
+		}, nil)
+
+	metricLoadBalancerVipBackendsNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "load_balancer_vip_backends_num"),
+		"The number of backends of a VIP of the OVN load balancer.",
+		[]string{
+			"uuid",
+			"load_balancer_name",
+			"vip",
+		}, nil)
+
+	metricLoadBalancerReference = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "load_balancer_reference"),
+		"Provides the association between a load balancer and the logical switch, logical router or load balancer group it is applied to. This metric is always up (1).",
+		[]string{
+			"uuid",
+			"load_balancer_name",
+			"type",
+			"reference_uuid",
+			"reference_name",
+		}, nil)
+
+	metricServiceMonitorOnline = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "service_monitor_online"),
+		"Is the load balancer backend checked by the service monitor online (1) or not (0).",
+		[]string{
+			"ip",
+			"port",
+			"protocol",
+			"logical_port",
+			"status",
+		}, nil)
+
+	// OVN ACL metrics
+	metricACLNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "acl_num"),
+		"The number of ACLs applied to a logical switch or port group by direction, action and priority band.",
+		[]string{
+			"owner_type",
+			"owner_name",
+			"direction",
+			"action",
+			"priority_band",
+		}, nil)
+
+	metricPortGroupPortsNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "port_group_ports_num"),
+		"The number of logical switch ports in the OVN port group.",
+		[]string{
+			"uuid",
+			"port_group_name",
+		}, nil)
+
+	metricPortGroupACLsNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "port_group_acls_num"),
+		"The number of ACLs applied to the OVN port group.",
+		[]string{
+			"uuid",
+			"port_group_name",
+		}, nil)
+
+	metricAddressSetAddressesNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "address_set_addresses_num"),
+		"The number of addresses in the OVN address set.",
+		[]string{
+			"uuid",
+			"address_set_name",
+		}, nil)
+
+	// OVN logical flow metrics
+	metricLogicalFlowNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_flow_num"),
+		"The number of logical flows in the southbound database by datapath, pipeline and table. Flows applied to a datapath group have the datapath_type dp_group.",
+		[]string{
+			"datapath",
+			"datapath_type",
+			"pipeline",
+			"table_id",
+			"stage",
+		}, nil)
+
+	metricLogicalDPGroupDatapathsNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_dp_group_datapaths_num"),
+		"The number of datapaths in the logical datapath group.",
+		[]string{
+			"uuid",
+		}, nil)
+
+	metricLogicalDPGroupFlowsNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "logical_dp_group_flows_num"),
+		"The number of logical flows applied to the logical datapath group.",
+		[]string{
+			"uuid",
+		}, nil)
+
+	// OVN port binding metrics
+	metricPortBindingUp = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "port_binding_up"),
+		"Is the port binding up (1) or not (0), along with the chassis that claimed it.",
+		[]string{
+			"uuid",
+			"logical_port",
+			"type",
+			"datapath",
+			"chassis",
+		}, nil)
+
+	metricPortBindingRequestedChassisMismatch = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "port_binding_requested_chassis_mismatch"),
+		"Is the port binding claimed by another chassis than its requested chassis (1) or not (0). Only exported for port bindings with a requested chassis.",
+		[]string{
+			"uuid",
+			"logical_port",
+			"requested_chassis",
+			"chassis",
+		}, nil)
+
+	metricPortBindingsNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "port_bindings_num"),
+		"The number of port bindings by type, vif for the ports of VMs and containers.",
+		[]string{
+			"type",
+		}, nil)
+
+	// OVN MAC binding and FDB metrics
+	metricMacBindingsNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "mac_bindings_num"),
+		"The number of MAC_Binding entries by datapath and logical port.",
+		[]string{
+			"datapath",
+			"logical_port",
+		}, nil)
+
+	metricMacBindingAge = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "mac_binding_age_seconds"),
+		"The age distribution of the MAC_Binding entries, only exported when the schema has the timestamp column.",
+		nil, nil)
+
+	metricFdbEntriesNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "fdb_entries_num"),
+		"The number of FDB entries by datapath and logical port.",
+		[]string{
+			"datapath",
+			"logical_port",
+		}, nil)
+
+	// OVN configuration propagation metrics
+	metricNbGlobalCfg = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "nb_global_cfg"),
+		"The configuration sequence numbers of NB_Global: nb_cfg as requested, sb_cfg as applied to the southbound database and hv_cfg as applied by all chassis.",
+		[]string{
+			"cfg",
+		}, nil)
+
+	metricNbGlobalCfgTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "nb_global_cfg_timestamp_seconds"),
+		"Unix time when the configuration sequence number of NB_Global was last updated.",
+		[]string{
+			"cfg",
+		}, nil)
+
+	metricSbGlobalNbCfg = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "sb_global_nb_cfg"),
+		"The configuration sequence number of SB_Global the chassis have to catch up with.",
+		nil, nil)
+
+	metricNbCfgBehind = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "nb_cfg_behind"),
+		"The number of configuration sequence numbers the southbound database (sb) or the slowest chassis (hv) is behind NB_Global.nb_cfg.",
+		[]string{
+			"stage",
+		}, nil)
+
+	metricNbCfgPropagation = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "nb_cfg_propagation_seconds"),
+		"The time in seconds the last NB_Global.nb_cfg took to reach the southbound database (sb) or all chassis (hv), or has been pending so far.",
+		[]string{
+			"stage",
+		}, nil)
+
+	// OVN IC metrics
+	metricICTransitSwitchInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ic_transit_switch_info"),
+		"The information about the transit switch of the interconnection northbound database. This metric is always up (1).",
+		[]string{
+			"uuid",
+			"transit_switch_name",
+		}, nil)
+
+	metricICAvailabilityZoneInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ic_availability_zone_info"),
+		"The information about the availability zone of the interconnection southbound database. This metric is always up (1).",
+		[]string{
+			"uuid",
+			"availability_zone_name",
+		}, nil)
+
+	metricICGatewayInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ic_gateway_info"),
+		"The information about the interconnection gateway. This metric is always up (1).",
+		[]string{
+			"availability_zone_name",
+			"gateway_name",
+			"hostname",
+		}, nil)
+
+	metricICGatewaysNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ic_gateways_num"),
+		"The number of interconnection gateways of the availability zone.",
+		[]string{
+			"uuid",
+			"availability_zone_name",
+		}, nil)
+
+	metricICRoutesNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ic_routes_num"),
+		"The number of routes the availability zone advertises by origin (connected, static or loadbalancer).",
+		[]string{
+			"uuid",
+			"availability_zone_name",
+			"origin",
+		}, nil)
+
+	metricICPortBindingsNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ic_port_bindings_num"),
+		"The number of ports of the availability zone bound to the transit switch.",
+		[]string{
+			"uuid",
+			"availability_zone_name",
+			"transit_switch_name",
+		}, nil)
+
+	// ovsdb-server metrics
+	metricDBMemory = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "db_memory_usage"),
+		"The memory usage of the ovsdb-server serving the database by item, such as cells, monitors, sessions, txn-history, raft-log, backlog and atoms.",
+		[]string{
+			"db_name",
+			"item",
+		}, nil)
+
+	metricDBCoverage = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "db_coverage_total"),
+		"The number of times a coverage event occurred in the ovsdb-server serving the database.",
+		[]string{
+			"db_name",
+			"event",
+		}, nil)
+
+	// ovn-northd metrics
+	metricNorthdIncEngineRuns = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "northd_inc_engine_node_runs_total"),
+		"The number of runs of an ovn-northd incremental processing engine node by type (recompute, compute, cancel or abort).",
+		[]string{
+			"node",
+			"type",
+		}, nil)
+
+	metricNorthdStopwatch = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "northd_stopwatch_seconds"),
+		"The duration statistics of an ovn-northd stopwatch, such as the main loop or the lflow generation, by stat (max, min, p95, short_term_avg and long_term_avg).",
+		[]string{
+			"stopwatch",
+			"stat",
+		}, nil)
+
+	metricNorthdStopwatchSamples = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "northd_stopwatch_samples_total"),
+		"The number of samples taken by an ovn-northd stopwatch.",
+		[]string{
+			"stopwatch",
+		}, nil)
+
+	metricNorthdCoverage = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "northd_coverage_total"),
+		"The number of times an ovn-northd coverage event occurred.",
+		[]string{
+			"event",
+		}, nil)
+
+	// ovn-controller metrics
+	metricControllerInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "controller_info"),
+		"The information about the ovn-controller of the chassis. This metric is always up (1).",
+		[]string{
+			"chassis_name",
+			"bridge",
+		}, nil)
+
+	metricControllerSBConnected = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "controller_southbound_connected"),
+		"Whether ovn-controller is connected to the southbound database (1) or not (0).",
+		nil, nil)
+
+	metricControllerNbCfg = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "controller_nb_cfg"),
+		"The northbound configuration sequence number ovn-controller last caught up with.",
+		nil, nil)
+
+	metricControllerIncEngineRuns = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "controller_inc_engine_node_runs_total"),
+		"The number of runs of an ovn-controller incremental processing engine node by type (recompute, compute, cancel or abort).",
+		[]string{
+			"node",
+			"type",
+		}, nil)
+
+	metricControllerLflowCacheEnabled = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "controller_lflow_cache_enabled"),
+		"Whether the logical flow cache of ovn-controller is enabled (1) or not (0).",
+		nil, nil)
+
+	metricControllerLflowCacheEntries = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "controller_lflow_cache_entries"),
+		"The number of entries in the logical flow cache of ovn-controller by type, such as expr, matches and conj-id.",
+		[]string{
+			"type",
+		}, nil)
+
+	metricControllerLflowCacheHighWatermark = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "controller_lflow_cache_high_watermark"),
+		"The highest number of entries the logical flow cache of ovn-controller held.",
+		nil, nil)
+
+	metricControllerLflowCacheTrims = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "controller_lflow_cache_trims_total"),
+		"The number of times the logical flow cache of ovn-controller was trimmed.",
+		nil, nil)
+
+	metricControllerLflowCacheMemory = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "controller_lflow_cache_memory_bytes"),
+		"The memory used by the logical flow cache of ovn-controller.",
+		nil, nil)
+
+	metricControllerCoverage = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "controller_coverage_total"),
+		"The number of times an ovn-controller coverage event occurred.",
+		[]string{
+			"event",
+		}, nil)
+
+	metricControllerMemory = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "controller_memory_usage"),
+		"The memory usage of ovn-controller by item, such as idl cells, lflow cache entries and desired flows.",
+		[]string{
+			"item",
+		}, nil)
+
+	// Open vSwitch metrics
+	metricOvsBridgesNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ovs_bridges_num"),
+		"The number of bridges of the local Open vSwitch.",
+		nil, nil)
+
+	metricOvsBridgePortsNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ovs_bridge_ports_num"),
+		"The number of ports of the Open vSwitch bridge.",
+		[]string{
+			"uuid",
+			"bridge_name",
+			"datapath_type",
+		}, nil)
+
+	metricOvsInterfaceAdminState = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ovs_interface_admin_state"),
+		"The administrative state of the Open vSwitch interface, up (1) or down (0).",
+		[]string{
+			"uuid",
+			"interface_name",
+			"bridge_name",
+			"type",
+		}, nil)
+
+	metricOvsInterfaceLinkState = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ovs_interface_link_state"),
+		"The link state of the Open vSwitch interface, up (1) or down (0).",
+		[]string{
+			"uuid",
+			"interface_name",
+			"bridge_name",
+			"type",
+		}, nil)
+
+	metricOvsInterfaceError = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ovs_interface_error"),
+		"The information about the error ovs-vswitchd reported for the Open vSwitch interface. This metric is always up (1).",
+		[]string{
+			"uuid",
+			"interface_name",
+			"bridge_name",
+			"error",
+		}, nil)
+
+	metricOvsInterfaceOfportFailedNum = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ovs_interface_ofport_failed_num"),
+		"The number of interfaces of the Open vSwitch bridge ovs-vswitchd failed to allocate an OpenFlow port (-1) for.",
+		[]string{
+			"uuid",
+			"bridge_name",
+		}, nil)
+
+	metricOvsDatapathLookups = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ovs_datapath_lookups_total"),
+		"The number of packets looked up in the datapath flow table by result (hit, missed or lost).",
+		[]string{
+			"datapath",
+			"result",
+		}, nil)
+
+	metricOvsDatapathFlows = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ovs_datapath_flows"),
+		"The number of flows in the datapath by stat (current, avg and max).",
+		[]string{
+			"datapath",
+			"stat",
+		}, nil)
+
+	metricOvsDatapathFlowLimit = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ovs_datapath_flow_limit"),
+		"The maximum number of flows ovs-vswitchd keeps in the datapath.",
+		[]string{
+			"datapath",
+		}, nil)
+
+	metricOvsUpcallDumpDuration = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ovs_upcall_dump_duration_seconds"),
+		"The duration of the last dump of the datapath flows by the revalidators.",
+		[]string{
+			"datapath",
+		}, nil)
+
+	metricOvsUpcallHandlerKeys = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "ovs_upcall_handler_keys"),
+		"The number of datapath flow keys handled by the revalidator.",
+		[]string{
+			"datapath",
+			"handler",
+		}, nil)
 
 	// OVN Cluster basic info metrics
-	metricClusterEnabled = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_enabled",
-			Help:      "Is OVN clustering enabled (1) or not (0).",
-		},
+	metricClusterEnabled = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_enabled"),
+		"Is OVN clustering enabled (1) or not (0).",
 		[]string{
 			"db_name",
-		})
+		}, nil)
 
-	metricClusterRole = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_role",
-			Help:      "A metric with a constant '1' value labeled by server role.",
-		},
+	metricClusterRole = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_role"),
+		"A metric with a constant '1' value labeled by server role.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
 			"server_role",
-		})
+		}, nil)
 
-	metricClusterStatus = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_status",
-			Help:      "A metric with a constant '1' value labeled by server status.",
-		},
+	metricClusterStatus = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_status"),
+		"A metric with a constant '1' value labeled by server status.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
 			"server_status",
-		})
+		}, nil)
 
-	metricClusterTerm = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_term",
-			Help:      "The current raft term known by this server.",
-		},
+	metricClusterTerm = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_term"),
+		"The current raft term known by this server.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+		}, nil)
 
-	metricClusterLeaderSelf = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_leader_self",
-			Help:      "Is this server consider itself a leader (1) or not (0).",
-		},
+	metricClusterLeaderSelf = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_leader_self"),
+		"Is this server consider itself a leader (1) or not (0).",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+		}, nil)
 
-	metricClusterVoteSelf = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_vote_self",
-			Help:      "Is this server voted itself as a leader (1) or not (0).",
-		},
+	metricClusterVoteSelf = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_vote_self"),
+		"Is this server voted itself as a leader (1) or not (0).",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+		}, nil)
 
-	metricClusterElectionTimer = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_election_timer",
-			Help:      "The current election timer value.",
-		},
+	metricClusterElectionTimer = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_election_timer"),
+		"The current election timer value.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+		}, nil)
 
-	metricClusterNotCommittedEntryCount = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_log_not_committed",
-			Help:      "The number of log entries not yet committed by this server.",
-		},
+	metricClusterNotCommittedEntryCount = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_log_not_committed"),
+		"The number of log entries not yet committed by this server.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+		}, nil)
 
-	metricClusterNotAppliedEntryCount = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_log_not_applied",
-			Help:      "The number of log entries not yet applied by this server.",
-		},
+	metricClusterNotAppliedEntryCount = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_log_not_applied"),
+		"The number of log entries not yet applied by this server.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+		}, nil)
 
-	metricClusterLogIndexStart = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_log_index_start",
-			Help:      "The log entry index start value associated with this server.",
-		},
+	metricClusterLogIndexStart = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_log_index_start"),
+		"The log entry index start value associated with this server.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+		}, nil)
 
-	metricClusterLogIndexNext = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_log_index_next",
-			Help:      "The log entry index next value associated with this server.",
-		},
+	metricClusterLogIndexNext = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_log_index_next"),
+		"The log entry index next value associated with this server.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+		}, nil)
 
-	metricClusterInConnTotal = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_inbound_connections_total",
-			Help:      "The total number of inbound connections to the server.",
-		},
+	metricClusterInConnTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_inbound_connections_total"),
+		"The total number of inbound connections to the server.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+		}, nil)
 
-	metricClusterOutConnTotal = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_outbound_connections_total",
-			Help:      "The total number of outbound connections from the server.",
-		},
+	metricClusterOutConnTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_outbound_connections_total"),
+		"The total number of outbound connections from the server.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+		}, nil)
 
-	metricClusterInConnErrTotal = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_inbound_connections_error_total",
-			Help:      "The total number of failed inbound connections to the server.",
-		},
+	metricClusterInConnErrTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_inbound_connections_error_total"),
+		"The total number of failed inbound connections to the server.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+		}, nil)
 
-	metricClusterOutConnErrTotal = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_outbound_connections_error_total",
-			Help:      "The total number of failed outbound connections from the server.",
-		},
+	metricClusterOutConnErrTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_outbound_connections_error_total"),
+		"The total number of failed outbound connections from the server.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+		}, nil)
 
-	// Todo: The metrics downside are to be implemented
-	metricClusterPeerInConnInfo = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_inbound_peer_connected",
-			Help:      "This metric appears when a cluster peer is connected to this server. This metric is always 1.",
-		},
+	// OVN Cluster peer metrics
+	metricClusterPeerInConnInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_inbound_peer_connected"),
+		"This metric appears when a cluster peer is connected to this server. This metric is always 1.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
 			"peer_id",
 			"peer_address",
-		})
+		}, nil)
 
-	metricClusterPeerOutConnInfo = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_outbound_peer_connected",
-			Help:      "This metric appears when this server connects to a cluster peer. This metric is always 1.",
-		},
+	metricClusterPeerOutConnInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_outbound_peer_connected"),
+		"This metric appears when this server connects to a cluster peer. This metric is always 1.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
 			"peer_id",
 			"peer_address",
-		})
+		}, nil)
+
+	metricClusterPeerCount = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_peer_count"),
+		"The total number of peers in this server's cluster.",
+		[]string{
+			"db_name",
+			"server_id",
+			"cluster_id",
+		}, nil)
 
-	metricClusterPeerCount = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_peer_count",
-			Help:      "The total number of peers in this server's cluster.",
-		},
+	metricClusterPeerNextIndex = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_peer_next_index"),
+		"The raft's next index associated with this cluster peer.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+			"peer_id",
+		}, nil)
 
-	metricClusterPeerNextIndex = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_peer_next_index",
-			Help:      "The raft's next index associated with this cluster peer.",
-		},
+	metricClusterPeerMatchIndex = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_peer_match_index"),
+		"The raft's match index associated with this cluster peer.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
 			"peer_id",
-		})
+		}, nil)
 
-	metricClusterPeerMatchIndex = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_peer_match_index",
-			Help:      "The raft's match index associated with this cluster peer.",
-		},
+	metricClusterPeerLastMsgAge = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_peer_last_msg_age_seconds"),
+		"The time since this server last received a message from the cluster peer.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
 			"peer_id",
-		})
+		}, nil)
 
-	metricClusterNextIndex = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_next_index",
-			Help:      "The raft's next index associated with this server.",
-		},
+	metricClusterNextIndex = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_next_index"),
+		"The raft's next index associated with this server.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+		}, nil)
 
-	metricClusterMatchIndex = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "cluster_match_index",
-			Help:      "The raft's match index associated with this server.",
-		},
+	metricClusterMatchIndex = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "cluster_match_index"),
+		"The raft's match index associated with this server.",
 		[]string{
 			"db_name",
 			"server_id",
 			"cluster_id",
-		})
+		}, nil)
+
+	// ovn-exporter metrics
+	metricDBConnected = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "exporter", "db_connected"),
+		"Is the exporter connected to the database (1) or not (0).",
+		[]string{
+			"db_name",
+		}, nil)
+
+	metricDBReconnects = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "exporter", "db_reconnects_total"),
+		"The number of times the exporter re-established a lost connection to the database.",
+		[]string{
+			"db_name",
+		}, nil)
+
+	metricCollectorDuration = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "exporter", "collector_duration_seconds"),
+		"Duration of the last run of the collector.",
+		[]string{
+			"collector",
+		}, nil)
+
+	metricCollectorSuccess = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "exporter", "collector_success"),
+		"Did the last run of the collector succeed (1) or not (0).",
+		[]string{
+			"collector",
+		}, nil)
+
+	metricCollectorLastSuccess = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "exporter", "collector_last_success_timestamp_seconds"),
+		"Unix time of the last successful run of the collector, 0 if it never succeeded.",
+		[]string{
+			"collector",
+		}, nil)
+
+	metricCollectionDuration = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "exporter", "collection_duration_seconds"),
+		"Duration of the last collection cycle across all collectors.",
+		nil, nil)
 
-	metricDBStatus = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Namespace: metricNamespace,
-			Name:      "db_status",
-			Help:      "The status of OVN NB/SB DB, (1) for healthy, (0) for unhealthy.",
-		},
+	metricDBStatus = prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "db_status"),
+		"The status of OVN NB/SB DB, (1) for healthy, (0) for unhealthy.",
 		[]string{
 			"db_name",
-		})
+		}, nil)
 )
 
-func registerOvnMetrics() {
+func describeOvnMetrics(ch chan<- *prometheus.Desc) {
 	// ovn status metrics
-	prometheus.MustRegister(metricOvnHealthyStatus)
-	prometheus.MustRegister(metricOvnHealthyStatusContent)
-	prometheus.MustRegister(metricRequestErrorNums)
-	prometheus.MustRegister(metricDBFileSize)
-	prometheus.MustRegister(metricDBStatus)
+	ch <- metricOvnHealthyStatus
+	ch <- metricOvnHealthyStatusContent
+	ch <- metricRequestErrorNums
+	ch <- metricDBFileSize
+	ch <- metricDBStatus
 
 	// ovn chassis metrics
-	prometheus.MustRegister(metricChassisInfo)
-	prometheus.MustRegister(metricLogicalSwitchInfo)
-	prometheus.MustRegister(metricLogicalSwitchExternalIDs)
-	prometheus.MustRegister(metricLogicalSwitchPortBinding)
-	prometheus.MustRegister(metricLogicalSwitchTunnelKey)
-	prometheus.MustRegister(metricLogicalSwitchPortsNum)
-	prometheus.MustRegister(metricLogicalSwitchPortInfo)
-	prometheus.MustRegister(metricLogicalSwitchPortTunnelKey)
+	ch <- metricChassisInfo
+	ch <- metricChassisEncapInfo
+	ch <- metricChassisEncapsNum
+	ch <- metricChassisNbCfg
+	ch <- metricChassisNbCfgLag
+	ch <- metricChassisNbCfgTimestampAge
+	ch <- metricChassisPortBindingsNum
+	ch <- metricChassisOtherConfig
+	ch <- metricLogicalSwitchInfo
+	ch <- metricLogicalSwitchExternalIDs
+	ch <- metricLogicalSwitchPortBinding
+	ch <- metricLogicalSwitchTunnelKey
+	ch <- metricLogicalSwitchPortsNum
+	ch <- metricLogicalSwitchPortInfo
+	ch <- metricLogicalSwitchPortTunnelKey
+
+	// ovn logical router metrics
+	ch <- metricLogicalRouterInfo
+	ch <- metricLogicalRouterPortsNum
+	ch <- metricLogicalRouterStaticRoutesNum
+	ch <- metricLogicalRouterPoliciesNum
+	ch <- metricLogicalRouterNatRulesNum
+	ch <- metricLogicalRouterLoadBalancersNum
+	ch <- metricLogicalRouterPortInfo
+	ch <- metricLogicalRouterPortGatewayChassisPriority
+	ch <- metricLogicalRouterPortGatewayChassisActive
+	ch <- metricLogicalRouterPortGatewayActive
+
+	// ovn NAT metrics
+	ch <- metricLogicalRouterNatTypeNum
+	ch <- metricNatDuplicateExternalIP
+	ch <- metricNatFipInfo
+
+	// ovn load balancer metrics
+	ch <- metricLoadBalancerInfo
+	ch <- metricLoadBalancerVipsNum
+	ch <- metricLoadBalancerVipBackendsNum
+	ch <- metricLoadBalancerReference
+	ch <- metricServiceMonitorOnline
+
+	// ovn ACL metrics
+	ch <- metricACLNum
+	ch <- metricPortGroupPortsNum
+	ch <- metricPortGroupACLsNum
+	ch <- metricAddressSetAddressesNum
+
+	// ovn port binding metrics
+	ch <- metricPortBindingUp
+	ch <- metricPortBindingRequestedChassisMismatch
+	ch <- metricPortBindingsNum
+
+	// ovn MAC binding and FDB metrics
+	ch <- metricMacBindingsNum
+	ch <- metricMacBindingAge
+	ch <- metricFdbEntriesNum
+
+	// ovn configuration propagation metrics
+	ch <- metricNbGlobalCfg
+	ch <- metricNbGlobalCfgTimestamp
+	ch <- metricSbGlobalNbCfg
+	ch <- metricNbCfgBehind
+	ch <- metricNbCfgPropagation
+
+	// ovn logical flow metrics
+	ch <- metricLogicalFlowNum
+	ch <- metricLogicalDPGroupDatapathsNum
+	ch <- metricLogicalDPGroupFlowsNum
+
+	// OVN IC metrics
+	ch <- metricICTransitSwitchInfo
+	ch <- metricICAvailabilityZoneInfo
+	ch <- metricICGatewayInfo
+	ch <- metricICGatewaysNum
+	ch <- metricICRoutesNum
+	ch <- metricICPortBindingsNum
+
+	// ovsdb-server metrics
+	ch <- metricDBMemory
+	ch <- metricDBCoverage
+
+	// ovn-northd metrics
+	ch <- metricNorthdIncEngineRuns
+	ch <- metricNorthdStopwatch
+	ch <- metricNorthdStopwatchSamples
+	ch <- metricNorthdCoverage
+
+	// ovn-controller metrics
+	ch <- metricControllerInfo
+	ch <- metricControllerSBConnected
+	ch <- metricControllerNbCfg
+	ch <- metricControllerIncEngineRuns
+	ch <- metricControllerLflowCacheEnabled
+	ch <- metricControllerLflowCacheEntries
+	ch <- metricControllerLflowCacheHighWatermark
+	ch <- metricControllerLflowCacheTrims
+	ch <- metricControllerLflowCacheMemory
+	ch <- metricControllerCoverage
+	ch <- metricControllerMemory
+
+	// Open vSwitch metrics
+	ch <- metricOvsBridgesNum
+	ch <- metricOvsBridgePortsNum
+	ch <- metricOvsInterfaceAdminState
+	ch <- metricOvsInterfaceLinkState
+	ch <- metricOvsInterfaceError
+	ch <- metricOvsInterfaceOfportFailedNum
+	ch <- metricOvsDatapathLookups
+	ch <- metricOvsDatapathFlows
+	ch <- metricOvsDatapathFlowLimit
+	ch <- metricOvsUpcallDumpDuration
+	ch <- metricOvsUpcallHandlerKeys
 
 	// OVN Cluster basic info metrics
-	prometheus.MustRegister(metricClusterEnabled)
-	prometheus.MustRegister(metricClusterRole)
-	prometheus.MustRegister(metricClusterStatus)
-	prometheus.MustRegister(metricClusterTerm)
-
-	prometheus.MustRegister(metricClusterLeaderSelf)
-	prometheus.MustRegister(metricClusterVoteSelf)
-	prometheus.MustRegister(metricClusterElectionTimer)
-	prometheus.MustRegister(metricClusterNotCommittedEntryCount)
-	prometheus.MustRegister(metricClusterNotAppliedEntryCount)
-
-	prometheus.MustRegister(metricClusterLogIndexStart)
-	prometheus.MustRegister(metricClusterLogIndexNext)
-	prometheus.MustRegister(metricClusterInConnTotal)
-	prometheus.MustRegister(metricClusterOutConnTotal)
-	prometheus.MustRegister(metricClusterInConnErrTotal)
-	prometheus.MustRegister(metricClusterOutConnErrTotal)
-
-	// to be implemented
-	prometheus.MustRegister(metricClusterPeerNextIndex)
-	prometheus.MustRegister(metricClusterPeerMatchIndex)
-	prometheus.MustRegister(metricClusterNextIndex)
-	prometheus.MustRegister(metricClusterMatchIndex)
-	prometheus.MustRegister(metricClusterPeerInConnInfo)
-	prometheus.MustRegister(metricClusterPeerOutConnInfo)
-	prometheus.MustRegister(metricClusterPeerCount)
+	ch <- metricClusterEnabled
+	ch <- metricClusterRole
+	ch <- metricClusterStatus
+	ch <- metricClusterTerm
+
+	ch <- metricClusterLeaderSelf
+	ch <- metricClusterVoteSelf
+	ch <- metricClusterElectionTimer
+	ch <- metricClusterNotCommittedEntryCount
+	ch <- metricClusterNotAppliedEntryCount
+
+	ch <- metricClusterLogIndexStart
+	ch <- metricClusterLogIndexNext
+	ch <- metricClusterInConnTotal
+	ch <- metricClusterOutConnTotal
+	ch <- metricClusterInConnErrTotal
+	ch <- metricClusterOutConnErrTotal
+
+	// OVN Cluster peer metrics
+	ch <- metricClusterPeerNextIndex
+	ch <- metricClusterPeerMatchIndex
+	ch <- metricClusterPeerLastMsgAge
+	ch <- metricClusterNextIndex
+	ch <- metricClusterMatchIndex
+	ch <- metricClusterPeerInConnInfo
+	ch <- metricClusterPeerOutConnInfo
+	ch <- metricClusterPeerCount
 }
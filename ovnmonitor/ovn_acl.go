@@ -0,0 +1,57 @@
+package ovnmonitor
+
+// OvnACL holds an ACL of the northbound database as applied to a logical
+// switch or port group. An ACL applied to several of them is returned once
+// for each.
+type OvnACL struct {
+	UUID      string
+	Direction string
+	Action    string
+	Priority  int
+	// OwnerType is logical_switch or port_group.
+	OwnerType string
+	OwnerName string
+}
+
+// getACLs returns the ACLs of the northbound database per logical switch
+// and port group they are applied to.
+func (e *Exporter) getACLs() ([]*OvnACL, error) {
+	rows, err := e.nbClient.Select("ACL", "_uuid", "direction", "action", "priority")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_acls", err)
+		return nil, err
+	}
+	byUUID := make(map[string]ovsdbRow, len(rows))
+	for _, row := range rows {
+		byUUID[row.String("_uuid")] = row
+	}
+
+	var acls []*OvnACL
+	for _, owner := range []struct{ table, ownerType string }{
+		{"Logical_Switch", "logical_switch"},
+		{"Port_Group", "port_group"},
+	} {
+		rows, err := e.nbClient.Select(owner.table, "name", "acls")
+		if err != nil {
+			e.countRequestError(e.nbClient.database, "get_acls", err)
+			return nil, err
+		}
+		for _, row := range rows {
+			for _, uuid := range row.Strings("acls") {
+				acl, ok := byUUID[uuid]
+				if !ok {
+					continue
+				}
+				acls = append(acls, &OvnACL{
+					UUID:      uuid,
+					Direction: acl.String("direction"),
+					Action:    acl.String("action"),
+					Priority:  int(acl.Float("priority")),
+					OwnerType: owner.ownerType,
+					OwnerName: row.String("name"),
+				})
+			}
+		}
+	}
+	return acls, nil
+}
@@ -0,0 +1,26 @@
+package ovnmonitor
+
+// OvnAddressSet holds an address set of the northbound database.
+type OvnAddressSet struct {
+	UUID      string
+	Name      string
+	Addresses []string
+}
+
+// getAddressSets returns the address sets of the northbound database.
+func (e *Exporter) getAddressSets() ([]*OvnAddressSet, error) {
+	rows, err := e.nbClient.Select("Address_Set", "_uuid", "name", "addresses")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_address_sets", err)
+		return nil, err
+	}
+	sets := make([]*OvnAddressSet, 0, len(rows))
+	for _, row := range rows {
+		sets = append(sets, &OvnAddressSet{
+			UUID:      row.String("_uuid"),
+			Name:      row.String("name"),
+			Addresses: row.Strings("addresses"),
+		})
+	}
+	return sets, nil
+}
@@ -0,0 +1,147 @@
+package ovnmonitor
+
+import "net"
+
+// OvnChassis holds a chassis of the southbound database. IPAddress is the
+// IP of its first tunnel endpoint.
+type OvnChassis struct {
+	UUID      string
+	Name      string
+	Hostname  string
+	IPAddress net.IP
+}
+
+// ovnChassisRows holds the Chassis and Encap rows of the southbound
+// database. They are selected once per collection and shared by getChassis
+// and getChassisHealth.
+type ovnChassisRows struct {
+	chassis []ovsdbRow
+	encaps  []ovsdbRow
+}
+
+// selectChassisRows selects the Chassis and Encap rows of the southbound
+// database.
+func (e *Exporter) selectChassisRows() (*ovnChassisRows, error) {
+	chassis, err := e.selectSbRows("Chassis")
+	if err != nil {
+		return nil, err
+	}
+	encaps, err := e.sbClient.Select("Encap", "_uuid", "chassis_name", "ip", "type")
+	if err != nil {
+		e.countRequestError(e.sbClient.database, "get_chassis", err)
+		return nil, err
+	}
+	return &ovnChassisRows{chassis: chassis, encaps: encaps}, nil
+}
+
+// getChassis returns the chassis registered in the southbound database
+// along with the tunnel endpoint of each of them.
+func (e *Exporter) getChassis(rows *ovnChassisRows) []*OvnChassis {
+	chassis := make([]*OvnChassis, 0, len(rows.chassis))
+	byName := make(map[string]*OvnChassis, len(rows.chassis))
+	for _, row := range rows.chassis {
+		c := &OvnChassis{
+			UUID:     row.String("_uuid"),
+			Hostname: row.String("hostname"),
+			Name:     row.String("name"),
+		}
+		chassis = append(chassis, c)
+		byName[c.Name] = c
+	}
+
+	for _, row := range rows.encaps {
+		c, ok := byName[row.String("chassis_name")]
+		// a chassis with several encaps reports the first one
+		if !ok || c.IPAddress != nil {
+			continue
+		}
+		c.IPAddress = net.ParseIP(row.String("ip"))
+	}
+	return chassis
+}
+
+// OvnEncap is a tunnel endpoint of a chassis.
+type OvnEncap struct {
+	Type string
+	IP   string
+}
+
+// OvnChassisHealth holds what a chassis reports about itself, for
+// alerting on chassis that fall behind the northbound configuration.
+type OvnChassisHealth struct {
+	UUID   string
+	Name   string
+	Encaps []OvnEncap
+	// HasPrivate is set when the chassis has a Chassis_Private row, only
+	// then NbCfg and NbCfgTimestamp are known.
+	HasPrivate bool
+	NbCfg      float64
+	// NbCfgTimestamp is the time in milliseconds when the chassis caught up
+	// with NbCfg.
+	NbCfgTimestamp float64
+	PortBindings   int
+	OtherConfig    map[string]string
+}
+
+// getChassisHealth returns the health of the chassis registered in the
+// southbound database along with the nb_cfg of the northbound database they
+// have to catch up with.
+func (e *Exporter) getChassisHealth(chassisRows *ovnChassisRows) ([]*OvnChassisHealth, float64, error) {
+	chassis := make([]*OvnChassisHealth, 0, len(chassisRows.chassis))
+	byName := make(map[string]*OvnChassisHealth, len(chassisRows.chassis))
+	byUUID := make(map[string]*OvnChassisHealth, len(chassisRows.chassis))
+	for _, row := range chassisRows.chassis {
+		c := &OvnChassisHealth{
+			UUID: row.String("_uuid"),
+			Name: row.String("name"),
+			// older versions of ovn-controller only set external_ids
+			OtherConfig: row.Map("external_ids"),
+		}
+		for k, v := range row.Map("other_config") {
+			c.OtherConfig[k] = v
+		}
+		chassis = append(chassis, c)
+		byName[c.Name] = c
+		byUUID[c.UUID] = c
+	}
+
+	for _, row := range chassisRows.encaps {
+		if c, ok := byName[row.String("chassis_name")]; ok {
+			c.Encaps = append(c.Encaps, OvnEncap{Type: row.String("type"), IP: row.String("ip")})
+		}
+	}
+
+	rows, err := e.sbClient.Select("Chassis_Private", "name", "nb_cfg", "nb_cfg_timestamp")
+	if err != nil {
+		e.countRequestError(e.sbClient.database, "get_chassis_health", err)
+		return nil, 0, err
+	}
+	for _, row := range rows {
+		if c, ok := byName[row.String("name")]; ok {
+			c.HasPrivate = true
+			c.NbCfg = row.Float("nb_cfg")
+			c.NbCfgTimestamp = row.Float("nb_cfg_timestamp")
+		}
+	}
+
+	rows, err = e.selectSbRows("Port_Binding")
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, row := range rows {
+		if c, ok := byUUID[row.String("chassis")]; ok {
+			c.PortBindings++
+		}
+	}
+
+	rows, err = e.nbClient.Select("NB_Global", "nb_cfg")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_chassis_health", err)
+		return nil, 0, err
+	}
+	var nbCfg float64
+	if len(rows) > 0 {
+		nbCfg = rows[0].Float("nb_cfg")
+	}
+	return chassis, nbCfg, nil
+}
@@ -0,0 +1,68 @@
+package ovnmonitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// defaultIntegrationBridge is the bridge ovn-controller manages when the
+// ovn-bridge key of the Open_vSwitch external_ids is not set.
+const defaultIntegrationBridge = "br-int"
+
+// OvnControllerChassis holds what the local Open_vSwitch database tells
+// about the chassis of ovn-controller. NbCfg is the northbound sequence
+// number ovn-controller last caught up with, it is negative before
+// ovn-controller reported one.
+type OvnControllerChassis struct {
+	Name   string
+	Bridge string
+	NbCfg  float64
+}
+
+// getControllerControlSocket returns the control socket of ovn-controller,
+// either the configured one or the one found through its pid file.
+func (e *Exporter) getControllerControlSocket() (string, error) {
+	if e.controllerSocketControl != "" {
+		return e.controllerSocketControl, nil
+	}
+	pid, err := os.ReadFile(e.controllerFilePidPath)
+	if err != nil {
+		return "", fmt.Errorf("read ovn-controller pid failed: %w", err)
+	}
+	// ovn-controller creates its control socket next to its pid file
+	runDir := filepath.Dir(e.controllerFilePidPath)
+	return filepath.Join(runDir, "ovn-controller."+strings.TrimSpace(string(pid))+".ctl"), nil
+}
+
+// getControllerChassis returns the chassis name from the system-id and the
+// nb_cfg ovn-controller wrote to the integration bridge.
+func (e *Exporter) getControllerChassis() (*OvnControllerChassis, error) {
+	rows, err := e.ovsClient.Select("Open_vSwitch", "external_ids")
+	if err != nil {
+		e.countRequestError(e.ovsClient.database, "get_controller_chassis", err)
+		return nil, err
+	}
+	chassis := &OvnControllerChassis{Bridge: defaultIntegrationBridge, NbCfg: -1}
+	if len(rows) > 0 {
+		externalIDs := rows[0].Map("external_ids")
+		chassis.Name = externalIDs["system-id"]
+		if bridge := externalIDs["ovn-bridge"]; bridge != "" {
+			chassis.Bridge = bridge
+		}
+	}
+
+	rows, err = e.ovsClient.SelectWhere("Bridge", [][]any{{"name", "==", chassis.Bridge}}, "external_ids")
+	if err != nil {
+		e.countRequestError(e.ovsClient.database, "get_controller_chassis", err)
+		return nil, err
+	}
+	if len(rows) > 0 {
+		if nbCfg, err := strconv.ParseFloat(rows[0].Map("external_ids")["ovn-nb-cfg"], 64); err == nil {
+			chassis.NbCfg = nbCfg
+		}
+	}
+	return chassis, nil
+}
@@ -0,0 +1,57 @@
+package ovnmonitor
+
+// getFdbCounts returns the number of FDB entries per datapath and logical
+// port. FDB entries refer to both by their tunnel keys. Older southbound
+// databases have no FDB table, nil is returned for them.
+func (e *Exporter) getFdbCounts() (map[OvnBindingKey]int, error) {
+	hasFdb, err := e.sbClient.HasColumn("FDB", "dp_key")
+	if err != nil {
+		e.countRequestError(e.sbClient.database, "get_fdb", err)
+		return nil, err
+	}
+	if !hasFdb {
+		return nil, nil
+	}
+
+	rows, err := e.selectSbRows("Datapath_Binding")
+	if err != nil {
+		return nil, err
+	}
+	type datapath struct {
+		name  string
+		ports map[int]string
+	}
+	byUUID := make(map[string]*datapath, len(rows))
+	byKey := make(map[int]*datapath, len(rows))
+	for _, row := range rows {
+		dp := &datapath{name: row.Map("external_ids")["name"], ports: make(map[int]string)}
+		byUUID[row.String("_uuid")] = dp
+		byKey[int(row.Float("tunnel_key"))] = dp
+	}
+
+	rows, err = e.selectSbRows("Port_Binding")
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if dp, ok := byUUID[row.String("datapath")]; ok {
+			dp.ports[int(row.Float("tunnel_key"))] = row.String("logical_port")
+		}
+	}
+
+	rows, err = e.sbClient.Select("FDB", "dp_key", "port_key")
+	if err != nil {
+		e.countRequestError(e.sbClient.database, "get_fdb", err)
+		return nil, err
+	}
+	counts := make(map[OvnBindingKey]int)
+	for _, row := range rows {
+		var key OvnBindingKey
+		if dp, ok := byKey[int(row.Float("dp_key"))]; ok {
+			key.Datapath = dp.name
+			key.LogicalPort = dp.ports[int(row.Float("port_key"))]
+		}
+		counts[key]++
+	}
+	return counts, nil
+}
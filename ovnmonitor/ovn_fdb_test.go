@@ -0,0 +1,57 @@
+package ovnmonitor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFdbInfoMetric(t *testing.T) {
+	sb := newTestOvsdbClient(t, "OVN_Southbound", `{
+		"Datapath_Binding": [
+			{"_uuid": ["uuid", "dp1"], "external_ids": ["map", [["name", "sw0"]]], "tunnel_key": 1},
+			{"_uuid": ["uuid", "dp2"], "external_ids": ["map", [["name", "sw1"]]], "tunnel_key": 2}
+		],
+		"Port_Binding": [
+			{"datapath": ["uuid", "dp1"], "tunnel_key": 1, "logical_port": "sw0-port1"},
+			{"datapath": ["uuid", "dp1"], "tunnel_key": 2, "logical_port": "sw0-localnet"},
+			{"datapath": ["uuid", "dp2"], "tunnel_key": 1, "logical_port": "sw1-port1"}
+		],
+		"FDB": [
+			{"dp_key": 1, "port_key": 2, "mac": "00:00:00:00:00:01"},
+			{"dp_key": 1, "port_key": 2, "mac": "00:00:00:00:00:02"},
+			{"dp_key": 2, "port_key": 1, "mac": "00:00:00:00:00:03"},
+			{"dp_key": 2, "port_key": 9, "mac": "00:00:00:00:00:04"},
+			{"dp_key": 3, "port_key": 1, "mac": "00:00:00:00:00:05"}
+		]
+	}`)
+	e := newTestExporter(nil, sb)
+
+	// FDB refers to datapaths and ports by tunnel key, port keys are only
+	// unique within a datapath; unknown keys are counted with empty labels
+	want := map[string]float64{
+		"datapath=sw0,logical_port=sw0-localnet": 2,
+		"datapath=sw1,logical_port=sw1-port1":    1,
+		"datapath=sw1,logical_port=":             1,
+		"datapath=,logical_port=":                1,
+	}
+	if got := collectMetrics(t, e.setFdbInfoMetric, metricFdbEntriesNum); !reflect.DeepEqual(got, want) {
+		t.Errorf("FDB entries = %v, want %v", got, want)
+	}
+}
+
+func TestFdbInfoMetricWithoutTable(t *testing.T) {
+	// older southbound databases have no FDB table
+	sb := newTestOvsdbClient(t, "OVN_Southbound", `{
+		"Datapath_Binding": [
+			{"_uuid": ["uuid", "dp1"], "external_ids": ["map", [["name", "sw0"]]], "tunnel_key": 1}
+		]
+	}`)
+	e := newTestExporter(nil, sb)
+
+	if got := collectMetrics(t, e.setFdbInfoMetric, metricFdbEntriesNum); len(got) != 0 {
+		t.Errorf("FDB entries = %v, want none", got)
+	}
+	if len(e.requestErrors) != 0 {
+		t.Errorf("request errors = %v, want none", e.requestErrors)
+	}
+}
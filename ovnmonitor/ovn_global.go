@@ -0,0 +1,46 @@
+package ovnmonitor
+
+// OvnGlobal holds the configuration sequence numbers of NB_Global and
+// SB_Global. ovn-nbctl --wait bumps NbCfg, northd copies it to SbCfg once
+// the southbound database is updated and HvCfg once every chassis caught
+// up. The timestamps are in milliseconds.
+type OvnGlobal struct {
+	NbCfg          float64
+	NbCfgTimestamp float64
+	SbCfg          float64
+	SbCfgTimestamp float64
+	HvCfg          float64
+	HvCfgTimestamp float64
+	// SbNbCfg is the nb_cfg of SB_Global, the sequence number the chassis
+	// have to catch up with.
+	SbNbCfg float64
+}
+
+// getGlobal returns the configuration sequence numbers of the northbound
+// and southbound database.
+func (e *Exporter) getGlobal() (*OvnGlobal, error) {
+	rows, err := e.nbClient.Select("NB_Global", "nb_cfg", "nb_cfg_timestamp", "sb_cfg", "sb_cfg_timestamp", "hv_cfg", "hv_cfg_timestamp")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_global", err)
+		return nil, err
+	}
+	global := &OvnGlobal{}
+	if len(rows) > 0 {
+		global.NbCfg = rows[0].Float("nb_cfg")
+		global.NbCfgTimestamp = rows[0].Float("nb_cfg_timestamp")
+		global.SbCfg = rows[0].Float("sb_cfg")
+		global.SbCfgTimestamp = rows[0].Float("sb_cfg_timestamp")
+		global.HvCfg = rows[0].Float("hv_cfg")
+		global.HvCfgTimestamp = rows[0].Float("hv_cfg_timestamp")
+	}
+
+	rows, err = e.sbClient.Select("SB_Global", "nb_cfg")
+	if err != nil {
+		e.countRequestError(e.sbClient.database, "get_global", err)
+		return nil, err
+	}
+	if len(rows) > 0 {
+		global.SbNbCfg = rows[0].Float("nb_cfg")
+	}
+	return global, nil
+}
@@ -0,0 +1,64 @@
+package ovnmonitor
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGlobalInfoMetric(t *testing.T) {
+	nbCfgTimestamp := time.Now().Add(-10 * time.Second).UnixMilli()
+	nb := newTestOvsdbClient(t, "OVN_Northbound", fmt.Sprintf(`{
+		"NB_Global": [{
+			"nb_cfg": 7, "nb_cfg_timestamp": %d,
+			"sb_cfg": 7, "sb_cfg_timestamp": %d,
+			"hv_cfg": 5, "hv_cfg_timestamp": %d
+		}]
+	}`, nbCfgTimestamp, nbCfgTimestamp+1500, nbCfgTimestamp-60000))
+	sb := newTestOvsdbClient(t, "OVN_Southbound", `{"SB_Global": [{"nb_cfg": 7}]}`)
+	e := newTestExporter(nb, sb)
+
+	want := map[string]float64{"stage=sb": 0, "stage=hv": 2}
+	if got := collectMetrics(t, e.setGlobalInfoMetric, metricNbCfgBehind); !reflect.DeepEqual(got, want) {
+		t.Errorf("nb_cfg behind = %v, want %v", got, want)
+	}
+
+	// sb caught up 1.5s after the request, hv is still pending since 10s
+	got := collectMetrics(t, e.setGlobalInfoMetric, metricNbCfgPropagation)
+	if got["stage=sb"] != 1.5 {
+		t.Errorf("sb propagation = %v, want 1.5", got["stage=sb"])
+	}
+	if hv := got["stage=hv"]; hv < 10 || hv > 15 {
+		t.Errorf("hv propagation = %v, want the time since the request, about 10", hv)
+	}
+
+	want = map[string]float64{"cfg=nb_cfg": 7, "cfg=sb_cfg": 7, "cfg=hv_cfg": 5}
+	if got := collectMetrics(t, e.setGlobalInfoMetric, metricNbGlobalCfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("NB_Global cfg = %v, want %v", got, want)
+	}
+}
+
+func TestGlobalInfoMetricNeverWaited(t *testing.T) {
+	nb := newTestOvsdbClient(t, "OVN_Northbound", `{
+		"NB_Global": [{
+			"nb_cfg": 0, "nb_cfg_timestamp": 0,
+			"sb_cfg": 0, "sb_cfg_timestamp": 0,
+			"hv_cfg": 0, "hv_cfg_timestamp": 0
+		}]
+	}`)
+	sb := newTestOvsdbClient(t, "OVN_Southbound", `{"SB_Global": [{"nb_cfg": 0}]}`)
+	e := newTestExporter(nb, sb)
+
+	// without ovn-nbctl --wait nothing was propagated
+	if got := collectMetrics(t, e.setGlobalInfoMetric, metricNbCfgPropagation); len(got) != 0 {
+		t.Errorf("propagation = %v, want none", got)
+	}
+	if got := collectMetrics(t, e.setGlobalInfoMetric, metricNbGlobalCfgTimestamp); len(got) != 0 {
+		t.Errorf("NB_Global cfg timestamps = %v, want none", got)
+	}
+	want := map[string]float64{"stage=sb": 0, "stage=hv": 0}
+	if got := collectMetrics(t, e.setGlobalInfoMetric, metricNbCfgBehind); !reflect.DeepEqual(got, want) {
+		t.Errorf("nb_cfg behind = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package ovnmonitor
+
+// OvnTransitSwitch holds a transit switch of the IC northbound database.
+type OvnTransitSwitch struct {
+	UUID string
+	Name string
+}
+
+// OvnICGateway holds a gateway chassis of an availability zone from the IC
+// southbound database.
+type OvnICGateway struct {
+	Name     string
+	Hostname string
+}
+
+// OvnAvailabilityZone holds an availability zone of the IC southbound
+// database along with its gateways, the number of routes it advertises by
+// origin and the number of its ports bound to each transit switch.
+type OvnAvailabilityZone struct {
+	UUID            string
+	Name            string
+	Gateways        []OvnICGateway
+	RoutesNum       map[string]int
+	PortBindingsNum map[string]int
+}
+
+// getTransitSwitches returns the transit switches of the IC northbound
+// database.
+func (e *Exporter) getTransitSwitches() ([]*OvnTransitSwitch, error) {
+	rows, err := e.icnbClient.Select("Transit_Switch", "_uuid", "name")
+	if err != nil {
+		e.countRequestError(e.icnbClient.database, "get_transit_switches", err)
+		return nil, err
+	}
+	switches := make([]*OvnTransitSwitch, 0, len(rows))
+	for _, row := range rows {
+		switches = append(switches, &OvnTransitSwitch{UUID: row.String("_uuid"), Name: row.String("name")})
+	}
+	return switches, nil
+}
+
+// getAvailabilityZones returns the availability zones of the IC southbound
+// database.
+func (e *Exporter) getAvailabilityZones() ([]*OvnAvailabilityZone, error) {
+	rows, err := e.icsbClient.Select("Availability_Zone", "_uuid", "name")
+	if err != nil {
+		e.countRequestError(e.icsbClient.database, "get_availability_zones", err)
+		return nil, err
+	}
+	zones := make([]*OvnAvailabilityZone, 0, len(rows))
+	byUUID := make(map[string]*OvnAvailabilityZone, len(rows))
+	for _, row := range rows {
+		az := &OvnAvailabilityZone{
+			UUID:            row.String("_uuid"),
+			Name:            row.String("name"),
+			RoutesNum:       make(map[string]int),
+			PortBindingsNum: make(map[string]int),
+		}
+		zones = append(zones, az)
+		byUUID[az.UUID] = az
+	}
+
+	rows, err = e.icsbClient.Select("Gateway", "name", "hostname", "availability_zone")
+	if err != nil {
+		e.countRequestError(e.icsbClient.database, "get_availability_zones", err)
+		return nil, err
+	}
+	for _, row := range rows {
+		if az, ok := byUUID[row.String("availability_zone")]; ok {
+			az.Gateways = append(az.Gateways, OvnICGateway{Name: row.String("name"), Hostname: row.String("hostname")})
+		}
+	}
+
+	rows, err = e.icsbClient.Select("Route", "origin", "availability_zone")
+	if err != nil {
+		e.countRequestError(e.icsbClient.database, "get_availability_zones", err)
+		return nil, err
+	}
+	for _, row := range rows {
+		if az, ok := byUUID[row.String("availability_zone")]; ok {
+			az.RoutesNum[row.String("origin")]++
+		}
+	}
+
+	rows, err = e.icsbClient.Select("Port_Binding", "transit_switch", "availability_zone")
+	if err != nil {
+		e.countRequestError(e.icsbClient.database, "get_availability_zones", err)
+		return nil, err
+	}
+	for _, row := range rows {
+		if az, ok := byUUID[row.String("availability_zone")]; ok {
+			az.PortBindingsNum[row.String("transit_switch")]++
+		}
+	}
+	return zones, nil
+}
@@ -0,0 +1,130 @@
+package ovnmonitor
+
+import (
+	"strings"
+)
+
+// OvnLoadBalancer holds a load balancer of the northbound database along
+// with the logical switches, logical routers and load balancer groups it is
+// applied to.
+type OvnLoadBalancer struct {
+	UUID     string
+	Name     string
+	Protocol string
+	// VIPs maps each VIP to its backends.
+	VIPs       map[string][]string
+	References []OvnLoadBalancerReference
+}
+
+// OvnLoadBalancerReference is a row applying a load balancer. Type is one
+// of logical_switch, logical_router or load_balancer_group. The name is not
+// unique, so the row is identified by its uuid.
+type OvnLoadBalancerReference struct {
+	Type string
+	UUID string
+	Name string
+}
+
+// OvnServiceMonitor holds a health check of a load balancer backend from the
+// southbound database.
+type OvnServiceMonitor struct {
+	IP          string
+	Port        string
+	Protocol    string
+	LogicalPort string
+	// Status is one of online, offline or error, empty before the first
+	// check.
+	Status string
+}
+
+// getLoadBalancers returns the load balancers of the northbound database.
+func (e *Exporter) getLoadBalancers() ([]*OvnLoadBalancer, error) {
+	rows, err := e.nbClient.Select("Load_Balancer", "_uuid", "name", "protocol", "vips")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_load_balancers", err)
+		return nil, err
+	}
+	lbs := make([]*OvnLoadBalancer, 0, len(rows))
+	byUUID := make(map[string]*OvnLoadBalancer, len(rows))
+	for _, row := range rows {
+		lb := &OvnLoadBalancer{
+			UUID:     row.String("_uuid"),
+			Name:     row.String("name"),
+			Protocol: row.String("protocol"),
+			VIPs:     make(map[string][]string),
+		}
+		// the protocol defaults to tcp when not set
+		if lb.Protocol == "" {
+			lb.Protocol = "tcp"
+		}
+		for vip, backends := range row.Map("vips") {
+			lb.VIPs[vip] = []string{}
+			for _, backend := range strings.Split(backends, ",") {
+				if backend = strings.TrimSpace(backend); backend != "" {
+					lb.VIPs[vip] = append(lb.VIPs[vip], backend)
+				}
+			}
+		}
+		lbs = append(lbs, lb)
+		byUUID[lb.UUID] = lb
+	}
+
+	// Next, find the switches, routers and groups referencing them. Load
+	// balancer groups only exist in newer versions of OVN.
+	hasGroups, err := e.nbClient.HasColumn("Load_Balancer_Group", "load_balancer")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_load_balancers", err)
+		return nil, err
+	}
+	references := []struct {
+		table   string
+		refType string
+	}{
+		{"Logical_Switch", "logical_switch"},
+		{"Logical_Router", "logical_router"},
+		{"Load_Balancer_Group", "load_balancer_group"},
+	}
+	for _, ref := range references {
+		if ref.table == "Load_Balancer_Group" && !hasGroups {
+			continue
+		}
+		rows, err := e.nbClient.Select(ref.table, "_uuid", "name", "load_balancer")
+		if err != nil {
+			e.countRequestError(e.nbClient.database, "get_load_balancers", err)
+			return nil, err
+		}
+		for _, row := range rows {
+			for _, uuid := range row.Strings("load_balancer") {
+				if lb, ok := byUUID[uuid]; ok {
+					lb.References = append(lb.References, OvnLoadBalancerReference{Type: ref.refType, UUID: row.String("_uuid"), Name: row.String("name")})
+				}
+			}
+		}
+	}
+	return lbs, nil
+}
+
+// getServiceMonitors returns the load balancer health checks of the
+// southbound database.
+func (e *Exporter) getServiceMonitors() ([]*OvnServiceMonitor, error) {
+	rows, err := e.sbClient.Select("Service_Monitor", "ip", "port", "protocol", "logical_port", "status")
+	if err != nil {
+		e.countRequestError(e.sbClient.database, "get_service_monitors", err)
+		return nil, err
+	}
+	monitors := make([]*OvnServiceMonitor, 0, len(rows))
+	for _, row := range rows {
+		m := &OvnServiceMonitor{
+			IP:          row.String("ip"),
+			Port:        row.String("port"),
+			Protocol:    row.String("protocol"),
+			LogicalPort: row.String("logical_port"),
+			Status:      row.String("status"),
+		}
+		if m.Protocol == "" {
+			m.Protocol = "tcp"
+		}
+		monitors = append(monitors, m)
+	}
+	return monitors, nil
+}
@@ -0,0 +1,86 @@
+package ovnmonitor
+
+import (
+	"strconv"
+)
+
+// OvnLogicalFlowKey groups the logical flows of the southbound database.
+// Flows applied to a datapath group have the datapath type dp_group and no
+// datapath name.
+type OvnLogicalFlowKey struct {
+	Datapath     string
+	DatapathType string
+	Pipeline     string
+	TableID      string
+	Stage        string
+}
+
+// OvnLogicalDPGroup holds a datapath group of the southbound database and
+// the number of logical flows applied to it.
+type OvnLogicalDPGroup struct {
+	UUID      string
+	Datapaths int
+	Flows     int
+}
+
+// getLogicalFlowCounts returns the number of logical flows of the southbound
+// database per datapath, pipeline and table together with the usage of the
+// datapath groups. The flows are counted right away, as there are far too
+// many of them to keep them around.
+func (e *Exporter) getLogicalFlowCounts() (map[OvnLogicalFlowKey]int, []*OvnLogicalDPGroup, error) {
+	rows, err := e.selectSbRows("Datapath_Binding")
+	if err != nil {
+		return nil, nil, err
+	}
+	type datapath struct{ name, kind string }
+	datapaths := make(map[string]datapath, len(rows))
+	for _, row := range rows {
+		externalIDs := row.Map("external_ids")
+		dp := datapath{name: externalIDs["name"]}
+		switch {
+		case externalIDs["logical-switch"] != "":
+			dp.kind = "logical_switch"
+		case externalIDs["logical-router"] != "":
+			dp.kind = "logical_router"
+		}
+		datapaths[row.String("_uuid")] = dp
+	}
+
+	rows, err = e.sbClient.Select("Logical_DP_Group", "_uuid", "datapaths")
+	if err != nil {
+		e.countRequestError(e.sbClient.database, "get_logical_flows", err)
+		return nil, nil, err
+	}
+	groups := make([]*OvnLogicalDPGroup, 0, len(rows))
+	byUUID := make(map[string]*OvnLogicalDPGroup, len(rows))
+	for _, row := range rows {
+		group := &OvnLogicalDPGroup{UUID: row.String("_uuid"), Datapaths: len(row.Strings("datapaths"))}
+		groups = append(groups, group)
+		byUUID[group.UUID] = group
+	}
+
+	// A large southbound database has hundreds of thousands of flows, so
+	// they get a longer deadline than the other tables.
+	rows, err = e.sbClient.SelectTimeout(e.logicalFlowTimeout, "Logical_Flow", "logical_datapath", "logical_dp_group", "pipeline", "table_id", "external_ids")
+	if err != nil {
+		e.countRequestError(e.sbClient.database, "get_logical_flows", err)
+		return nil, nil, err
+	}
+	counts := make(map[OvnLogicalFlowKey]int)
+	for _, row := range rows {
+		key := OvnLogicalFlowKey{
+			Pipeline: row.String("pipeline"),
+			TableID:  strconv.Itoa(int(row.Float("table_id"))),
+			Stage:    row.Map("external_ids")["stage-name"],
+		}
+		if group, ok := byUUID[row.String("logical_dp_group")]; ok {
+			group.Flows++
+			key.DatapathType = "dp_group"
+		} else {
+			dp := datapaths[row.String("logical_datapath")]
+			key.Datapath, key.DatapathType = dp.name, dp.kind
+		}
+		counts[key]++
+	}
+	return counts, groups, nil
+}
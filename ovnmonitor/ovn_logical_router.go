@@ -0,0 +1,34 @@
+package ovnmonitor
+
+// OvnLogicalRouter holds a logical router of the northbound database.
+type OvnLogicalRouter struct {
+	UUID          string
+	Name          string
+	Ports         []string
+	StaticRoutes  []string
+	Policies      []string
+	Nat           []string
+	LoadBalancers []string
+}
+
+// getLogicalRouters returns the logical routers of the northbound database.
+func (e *Exporter) getLogicalRouters() ([]*OvnLogicalRouter, error) {
+	rows, err := e.nbClient.Select("Logical_Router", "_uuid", "name", "ports", "static_routes", "policies", "nat", "load_balancer")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_logical_routers", err)
+		return nil, err
+	}
+	routers := make([]*OvnLogicalRouter, 0, len(rows))
+	for _, row := range rows {
+		routers = append(routers, &OvnLogicalRouter{
+			UUID:          row.String("_uuid"),
+			Name:          row.String("name"),
+			Ports:         row.Strings("ports"),
+			StaticRoutes:  row.Strings("static_routes"),
+			Policies:      row.Strings("policies"),
+			Nat:           row.Strings("nat"),
+			LoadBalancers: row.Strings("load_balancer"),
+		})
+	}
+	return routers, nil
+}
@@ -0,0 +1,154 @@
+package ovnmonitor
+
+// OvnGatewayChassis is a chassis that may host a distributed gateway port,
+// from either Gateway_Chassis, the HA_Chassis of a HA_Chassis_Group or the
+// legacy options:redirect-chassis.
+type OvnGatewayChassis struct {
+	ChassisName string
+	Priority    float64
+}
+
+// OvnLogicalRouterPort holds a logical router port of the northbound
+// database. For a distributed gateway port GatewayChassis lists the
+// candidate chassis and ActiveChassis the one its chassisredirect port is
+// bound to in the southbound database, empty if none.
+type OvnLogicalRouterPort struct {
+	UUID              string
+	Name              string
+	MAC               string
+	Networks          []string
+	Peer              string
+	LogicalRouterName string
+	GatewayChassis    []OvnGatewayChassis
+	ActiveChassis     string
+}
+
+// IsGateway reports whether the port is a distributed gateway port.
+func (p *OvnLogicalRouterPort) IsGateway() bool {
+	return len(p.GatewayChassis) > 0
+}
+
+// chassisRedirectPrefix prefixes the name of the southbound chassisredirect
+// port binding northd creates for a distributed gateway port.
+const chassisRedirectPrefix = "cr-"
+
+// getLogicalRouterPorts returns the logical router ports of the northbound
+// database together with their gateway chassis.
+func (e *Exporter) getLogicalRouterPorts() ([]*OvnLogicalRouterPort, error) {
+	rows, err := e.nbClient.Select("Logical_Router", "name", "ports")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_logical_router_ports", err)
+		return nil, err
+	}
+	routerOfPort := make(map[string]string)
+	for _, row := range rows {
+		for _, port := range row.Strings("ports") {
+			routerOfPort[port] = row.String("name")
+		}
+	}
+
+	// Next, the candidates of the gateway ports, either listed directly or
+	// through a HA chassis group.
+	rows, err = e.nbClient.Select("Gateway_Chassis", "_uuid", "chassis_name", "priority")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_logical_router_ports", err)
+		return nil, err
+	}
+	gatewayChassis := make(map[string]OvnGatewayChassis, len(rows))
+	for _, row := range rows {
+		gatewayChassis[row.String("_uuid")] = OvnGatewayChassis{ChassisName: row.String("chassis_name"), Priority: row.Float("priority")}
+	}
+	rows, err = e.nbClient.Select("HA_Chassis", "_uuid", "chassis_name", "priority")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_logical_router_ports", err)
+		return nil, err
+	}
+	haChassis := make(map[string]OvnGatewayChassis, len(rows))
+	for _, row := range rows {
+		haChassis[row.String("_uuid")] = OvnGatewayChassis{ChassisName: row.String("chassis_name"), Priority: row.Float("priority")}
+	}
+	rows, err = e.nbClient.Select("HA_Chassis_Group", "_uuid", "ha_chassis")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_logical_router_ports", err)
+		return nil, err
+	}
+	haChassisGroups := make(map[string][]OvnGatewayChassis, len(rows))
+	for _, row := range rows {
+		for _, uuid := range row.Strings("ha_chassis") {
+			if c, ok := haChassis[uuid]; ok {
+				haChassisGroups[row.String("_uuid")] = append(haChassisGroups[row.String("_uuid")], c)
+			}
+		}
+	}
+
+	rows, err = e.nbClient.Select("Logical_Router_Port", "_uuid", "name", "mac", "networks", "peer", "gateway_chassis", "ha_chassis_group", "options")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_logical_router_ports", err)
+		return nil, err
+	}
+	ports := make([]*OvnLogicalRouterPort, 0, len(rows))
+	byName := make(map[string]*OvnLogicalRouterPort, len(rows))
+	for _, row := range rows {
+		port := &OvnLogicalRouterPort{
+			UUID:     row.String("_uuid"),
+			Name:     row.String("name"),
+			MAC:      row.String("mac"),
+			Networks: row.Strings("networks"),
+			Peer:     row.String("peer"),
+		}
+		port.LogicalRouterName = routerOfPort[port.UUID]
+		// Like northd, a HA chassis group replaces the gateway chassis and
+		// options:redirect-chassis is only used when neither is set, it
+		// then names the only candidate.
+		var candidates []OvnGatewayChassis
+		if group := row.String("ha_chassis_group"); group != "" {
+			candidates = haChassisGroups[group]
+		} else if uuids := row.Strings("gateway_chassis"); len(uuids) > 0 {
+			for _, uuid := range uuids {
+				if c, ok := gatewayChassis[uuid]; ok {
+					candidates = append(candidates, c)
+				}
+			}
+		} else if redirect := row.Map("options")["redirect-chassis"]; redirect != "" {
+			candidates = []OvnGatewayChassis{{ChassisName: redirect}}
+		}
+		seen := make(map[string]bool, len(candidates))
+		for _, c := range candidates {
+			if !seen[c.ChassisName] {
+				seen[c.ChassisName] = true
+				port.GatewayChassis = append(port.GatewayChassis, c)
+			}
+		}
+		ports = append(ports, port)
+		byName[port.Name] = port
+	}
+
+	// Next, find the chassis that currently hosts each gateway port.
+	rows, err = e.selectSbRows("Chassis")
+	if err != nil {
+		return nil, err
+	}
+	chassisName := make(map[string]string, len(rows))
+	for _, row := range rows {
+		chassisName[row.String("_uuid")] = row.String("name")
+	}
+	rows, err = e.selectSbRows("Port_Binding")
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if row.String("type") != "chassisredirect" {
+			continue
+		}
+		logicalPort := row.String("logical_port")
+		if len(logicalPort) <= len(chassisRedirectPrefix) {
+			continue
+		}
+		port, ok := byName[logicalPort[len(chassisRedirectPrefix):]]
+		if !ok {
+			continue
+		}
+		port.ActiveChassis = chassisName[row.String("chassis")]
+	}
+	return ports, nil
+}
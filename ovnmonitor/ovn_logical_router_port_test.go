@@ -0,0 +1,84 @@
+package ovnmonitor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLogicalRouterPortGatewayMetrics(t *testing.T) {
+	nb := newTestOvsdbClient(t, "OVN_Northbound", `{
+		"Logical_Router": [
+			{"name": "router-1", "ports": ["set", [["uuid", "lrp1"], ["uuid", "lrp2"], ["uuid", "lrp3"], ["uuid", "lrp4"]]]}
+		],
+		"Gateway_Chassis": [
+			{"_uuid": ["uuid", "gc1"], "chassis_name": "chassis-1", "priority": 20},
+			{"_uuid": ["uuid", "gc2"], "chassis_name": "chassis-2", "priority": 10},
+			{"_uuid": ["uuid", "gc3"], "chassis_name": "chassis-2", "priority": 15},
+			{"_uuid": ["uuid", "gc4"], "chassis_name": "chassis-1", "priority": 5}
+		],
+		"HA_Chassis": [
+			{"_uuid": ["uuid", "hc1"], "chassis_name": "chassis-2", "priority": 30},
+			{"_uuid": ["uuid", "hc2"], "chassis_name": "chassis-3", "priority": 20}
+		],
+		"HA_Chassis_Group": [
+			{"_uuid": ["uuid", "hcg1"], "ha_chassis": ["set", [["uuid", "hc1"], ["uuid", "hc2"]]]}
+		],
+		"Logical_Router_Port": [
+			{"_uuid": ["uuid", "lrp1"], "name": "lrp-ext1", "mac": "00:00:00:00:01:01", "networks": "172.16.0.1/24", "peer": ["set", []],
+			 "gateway_chassis": ["set", [["uuid", "gc1"], ["uuid", "gc2"]]], "ha_chassis_group": ["set", []]},
+			{"_uuid": ["uuid", "lrp2"], "name": "lrp-ext2", "mac": "00:00:00:00:01:02", "networks": "172.16.1.1/24", "peer": ["set", []],
+			 "gateway_chassis": ["set", [["uuid", "gc3"], ["uuid", "gc4"]]], "ha_chassis_group": ["uuid", "hcg1"]},
+			{"_uuid": ["uuid", "lrp3"], "name": "lrp-int", "mac": "00:00:00:00:01:03", "networks": "10.0.0.1/24", "peer": ["set", []],
+			 "gateway_chassis": ["set", []], "ha_chassis_group": ["set", []]},
+			{"_uuid": ["uuid", "lrp4"], "name": "lrp-ext4", "mac": "00:00:00:00:01:04", "networks": "172.16.4.1/24", "peer": ["set", []],
+			 "gateway_chassis": ["set", []], "ha_chassis_group": ["set", []], "options": ["map", [["redirect-chassis", "chassis-3"]]]}
+		]
+	}`)
+	sb := newTestOvsdbClient(t, "OVN_Southbound", `{
+		"Chassis": [
+			{"_uuid": ["uuid", "ch1"], "name": "chassis-1"},
+			{"_uuid": ["uuid", "ch2"], "name": "chassis-2"},
+			{"_uuid": ["uuid", "ch3"], "name": "chassis-3"}
+		],
+		"Port_Binding": [
+			{"logical_port": "cr-lrp-ext1", "type": "chassisredirect", "chassis": ["uuid", "ch1"]},
+			{"logical_port": "cr-lrp-ext2", "type": "chassisredirect", "chassis": ["set", []]},
+			{"logical_port": "cr-lrp-ext4", "type": "chassisredirect", "chassis": ["uuid", "ch3"]},
+			{"logical_port": "lrp-ext1", "type": "patch", "chassis": ["set", []]}
+		]
+	}`)
+	e := newTestExporter(nb, sb)
+
+	// the HA chassis group replaces the gateway chassis of lrp-ext2 and
+	// lrp-ext4 only has the legacy redirect-chassis option
+	want := map[string]float64{
+		"chassis_name=chassis-1,logical_router_name=router-1,port_name=lrp-ext1,uuid=lrp1": 20,
+		"chassis_name=chassis-2,logical_router_name=router-1,port_name=lrp-ext1,uuid=lrp1": 10,
+		"chassis_name=chassis-2,logical_router_name=router-1,port_name=lrp-ext2,uuid=lrp2": 30,
+		"chassis_name=chassis-3,logical_router_name=router-1,port_name=lrp-ext2,uuid=lrp2": 20,
+		"chassis_name=chassis-3,logical_router_name=router-1,port_name=lrp-ext4,uuid=lrp4": 0,
+	}
+	if got := collectMetrics(t, e.setLogicalRouterPortInfoMetric, metricLogicalRouterPortGatewayChassisPriority); !reflect.DeepEqual(got, want) {
+		t.Errorf("gateway chassis priorities = %v, want %v", got, want)
+	}
+
+	want = map[string]float64{
+		"logical_router_name=router-1,port_name=lrp-ext1,uuid=lrp1": 1,
+		"logical_router_name=router-1,port_name=lrp-ext2,uuid=lrp2": 0,
+		"logical_router_name=router-1,port_name=lrp-ext4,uuid=lrp4": 1,
+	}
+	if got := collectMetrics(t, e.setLogicalRouterPortInfoMetric, metricLogicalRouterPortGatewayActive); !reflect.DeepEqual(got, want) {
+		t.Errorf("active gateway ports = %v, want %v", got, want)
+	}
+
+	want = map[string]float64{
+		"chassis_name=chassis-1,logical_router_name=router-1,port_name=lrp-ext1,uuid=lrp1": 1,
+		"chassis_name=chassis-2,logical_router_name=router-1,port_name=lrp-ext1,uuid=lrp1": 0,
+		"chassis_name=chassis-2,logical_router_name=router-1,port_name=lrp-ext2,uuid=lrp2": 0,
+		"chassis_name=chassis-3,logical_router_name=router-1,port_name=lrp-ext2,uuid=lrp2": 0,
+		"chassis_name=chassis-3,logical_router_name=router-1,port_name=lrp-ext4,uuid=lrp4": 1,
+	}
+	if got := collectMetrics(t, e.setLogicalRouterPortInfoMetric, metricLogicalRouterPortGatewayChassisActive); !reflect.DeepEqual(got, want) {
+		t.Errorf("active gateway chassis = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,49 @@
+package ovnmonitor
+
+// OvnLogicalSwitch holds a logical switch of the northbound database along
+// with the tunnel key and uuid of its southbound datapath.
+type OvnLogicalSwitch struct {
+	UUID        string
+	Name        string
+	TunnelKey   uint64
+	DatapathID  string
+	ExternalIDs map[string]string
+	Ports       []string
+}
+
+// getLogicalSwitches returns the logical switches of the northbound database
+// together with the tunnel key of their southbound datapath.
+func (e *Exporter) getLogicalSwitches() ([]*OvnLogicalSwitch, error) {
+	rows, err := e.nbClient.Select("Logical_Switch", "_uuid", "external_ids", "name", "ports")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_logical_switches", err)
+		return nil, err
+	}
+	switches := make([]*OvnLogicalSwitch, 0, len(rows))
+	byUUID := make(map[string]*OvnLogicalSwitch, len(rows))
+	for _, row := range rows {
+		sw := &OvnLogicalSwitch{
+			UUID:        row.String("_uuid"),
+			Name:        row.String("name"),
+			ExternalIDs: row.Map("external_ids"),
+			Ports:       row.Strings("ports"),
+		}
+		switches = append(switches, sw)
+		byUUID[sw.UUID] = sw
+	}
+
+	// Next, obtain a tunnel key for the datapath associated with the switch.
+	rows, err = e.selectSbRows("Datapath_Binding")
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		sw, ok := byUUID[row.Map("external_ids")["logical-switch"]]
+		if !ok {
+			continue
+		}
+		sw.TunnelKey = uint64(row.Float("tunnel_key"))
+		sw.DatapathID = row.String("_uuid")
+	}
+	return switches, nil
+}
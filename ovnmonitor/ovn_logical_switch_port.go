@@ -0,0 +1,109 @@
+package ovnmonitor
+
+import (
+	"net"
+	"strings"
+)
+
+// OvnLogicalSwitchPortAddress is an entry of the addresses column of a
+// logical switch port.
+type OvnLogicalSwitchPortAddress struct {
+	MacAddress  net.HardwareAddr
+	IpAddresses []net.IP
+	Dynamic     bool
+	Router      bool
+	Unknown     bool
+}
+
+// OvnLogicalSwitchPort holds a logical switch port of the northbound
+// database along with its southbound port binding.
+type OvnLogicalSwitchPort struct {
+	UUID              string
+	Name              string
+	Addresses         []OvnLogicalSwitchPortAddress
+	ExternalIDs       map[string]string
+	TunnelKey         uint64
+	Up                bool
+	PortBindingUUID   string
+	ChassisUUID       string
+	DatapathUUID      string
+	LogicalSwitchName string
+}
+
+// parseLogicalPortAddress parses an entry of the addresses column of a
+// logical switch port, e.g. "0a:00:00:00:00:01 10.0.0.1" or "router".
+func parseLogicalPortAddress(s string) OvnLogicalSwitchPortAddress {
+	addrs := strings.Fields(s)
+	if len(addrs) == 0 {
+		return OvnLogicalSwitchPortAddress{}
+	}
+
+	switch addrs[0] {
+	case "router":
+		return OvnLogicalSwitchPortAddress{Router: true}
+	case "unknown":
+		return OvnLogicalSwitchPortAddress{Unknown: true}
+	case "dynamic":
+		// "dynamic" may be followed by requested IP addresses
+		portAddress := OvnLogicalSwitchPortAddress{Dynamic: true}
+		for _, v := range addrs[1:] {
+			portAddress.IpAddresses = append(portAddress.IpAddresses, net.ParseIP(v))
+		}
+		return portAddress
+	}
+
+	// In all other cases first entry should be a MAC address
+	macAddr, _ := net.ParseMAC(addrs[0])
+	portAddress := OvnLogicalSwitchPortAddress{MacAddress: macAddr}
+	if len(addrs) > 1 && addrs[1] == "dynamic" {
+		portAddress.Dynamic = true
+		return portAddress
+	}
+	for _, v := range addrs[1:] {
+		portAddress.IpAddresses = append(portAddress.IpAddresses, net.ParseIP(v))
+	}
+	return portAddress
+}
+
+// getLogicalSwitchPorts returns the logical switch ports of the northbound
+// database together with their southbound port binding.
+func (e *Exporter) getLogicalSwitchPorts() ([]*OvnLogicalSwitchPort, error) {
+	rows, err := e.nbClient.Select("Logical_Switch_Port", "_uuid", "addresses", "external_ids", "name", "up")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_logical_switch_ports", err)
+		return nil, err
+	}
+	ports := make([]*OvnLogicalSwitchPort, 0, len(rows))
+	byName := make(map[string]*OvnLogicalSwitchPort, len(rows))
+	for _, row := range rows {
+		port := &OvnLogicalSwitchPort{
+			UUID:        row.String("_uuid"),
+			Name:        row.String("name"),
+			Up:          row.Bool("up"),
+			ExternalIDs: row.Map("external_ids"),
+		}
+		port.LogicalSwitchName = port.ExternalIDs["ls"]
+		for _, s := range row.Strings("addresses") {
+			port.Addresses = append(port.Addresses, parseLogicalPortAddress(s))
+		}
+		ports = append(ports, port)
+		byName[port.Name] = port
+	}
+
+	// Next, gather tunnel ids and other details about the logical ports.
+	rows, err = e.selectSbRows("Port_Binding")
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		port, ok := byName[row.String("logical_port")]
+		if !ok {
+			continue
+		}
+		port.PortBindingUUID = row.String("_uuid")
+		port.ChassisUUID = row.String("chassis")
+		port.DatapathUUID = row.String("datapath")
+		port.TunnelKey = uint64(row.Float("tunnel_key"))
+	}
+	return ports, nil
+}
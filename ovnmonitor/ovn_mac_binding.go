@@ -0,0 +1,52 @@
+package ovnmonitor
+
+import (
+	"time"
+)
+
+// OvnBindingKey groups MAC_Binding and FDB entries of the southbound
+// database by datapath and logical port.
+type OvnBindingKey struct {
+	Datapath    string
+	LogicalPort string
+}
+
+// getMacBindingCounts returns the number of MAC_Binding entries per datapath
+// and logical port. When the schema has the timestamp column, it also
+// returns the age of every entry in seconds.
+func (e *Exporter) getMacBindingCounts() (map[OvnBindingKey]int, []float64, error) {
+	hasTimestamp, err := e.sbClient.HasColumn("MAC_Binding", "timestamp")
+	if err != nil {
+		e.countRequestError(e.sbClient.database, "get_mac_bindings", err)
+		return nil, nil, err
+	}
+
+	rows, err := e.selectSbRows("Datapath_Binding")
+	if err != nil {
+		return nil, nil, err
+	}
+	datapathName := make(map[string]string, len(rows))
+	for _, row := range rows {
+		datapathName[row.String("_uuid")] = row.Map("external_ids")["name"]
+	}
+
+	columns := []string{"datapath", "logical_port"}
+	if hasTimestamp {
+		columns = append(columns, "timestamp")
+	}
+	rows, err = e.sbClient.Select("MAC_Binding", columns...)
+	if err != nil {
+		e.countRequestError(e.sbClient.database, "get_mac_bindings", err)
+		return nil, nil, err
+	}
+	counts := make(map[OvnBindingKey]int)
+	var ages []float64
+	now := time.Now()
+	for _, row := range rows {
+		counts[OvnBindingKey{Datapath: datapathName[row.String("datapath")], LogicalPort: row.String("logical_port")}]++
+		if hasTimestamp {
+			ages = append(ages, now.Sub(time.UnixMilli(int64(row.Float("timestamp")))).Seconds())
+		}
+	}
+	return counts, ages, nil
+}
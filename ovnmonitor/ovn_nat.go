@@ -0,0 +1,55 @@
+package ovnmonitor
+
+// OvnNat holds a NAT rule of the northbound database.
+type OvnNat struct {
+	UUID              string
+	Type              string
+	LogicalIP         string
+	ExternalIP        string
+	ExternalMAC       string
+	LogicalPort       string
+	LogicalRouterUUID string
+	LogicalRouterName string
+}
+
+// ovnNatTypes are the types of NAT rules.
+var ovnNatTypes = []string{"snat", "dnat", "dnat_and_snat"}
+
+// getNatRules returns the NAT rules of the northbound database along with
+// the router they belong to.
+func (e *Exporter) getNatRules() ([]*OvnNat, error) {
+	rows, err := e.nbClient.Select("Logical_Router", "_uuid", "name", "nat")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_nat_rules", err)
+		return nil, err
+	}
+	routerOfNat := make(map[string]ovsdbRow)
+	for _, row := range rows {
+		for _, nat := range row.Strings("nat") {
+			routerOfNat[nat] = row
+		}
+	}
+
+	rows, err = e.nbClient.Select("NAT", "_uuid", "type", "logical_ip", "external_ip", "external_mac", "logical_port")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_nat_rules", err)
+		return nil, err
+	}
+	rules := make([]*OvnNat, 0, len(rows))
+	for _, row := range rows {
+		nat := &OvnNat{
+			UUID:        row.String("_uuid"),
+			Type:        row.String("type"),
+			LogicalIP:   row.String("logical_ip"),
+			ExternalIP:  row.String("external_ip"),
+			ExternalMAC: row.String("external_mac"),
+			LogicalPort: row.String("logical_port"),
+		}
+		if router, ok := routerOfNat[nat.UUID]; ok {
+			nat.LogicalRouterUUID = router.String("_uuid")
+			nat.LogicalRouterName = router.String("name")
+		}
+		rules = append(rules, nat)
+	}
+	return rules, nil
+}
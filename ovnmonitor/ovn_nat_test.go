@@ -0,0 +1,44 @@
+package ovnmonitor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNatInfoMetric(t *testing.T) {
+	nb := newTestOvsdbClient(t, "OVN_Northbound", `{
+		"Logical_Router": [
+			{"_uuid": ["uuid", "r1"], "name": "router-1", "nat": ["set", [["uuid", "n1"], ["uuid", "n2"], ["uuid", "n3"], ["uuid", "n4"]]]},
+			{"_uuid": ["uuid", "r2"], "name": "router-2", "nat": ["uuid", "n5"]}
+		],
+		"NAT": [
+			{"_uuid": ["uuid", "n1"], "type": "snat", "logical_ip": "10.0.0.0/24", "external_ip": "172.16.0.1", "external_mac": ["set", []], "logical_port": ["set", []]},
+			{"_uuid": ["uuid", "n2"], "type": "snat", "logical_ip": "10.0.1.0/24", "external_ip": "172.16.0.1", "external_mac": ["set", []], "logical_port": ["set", []]},
+			{"_uuid": ["uuid", "n3"], "type": "dnat_and_snat", "logical_ip": "10.0.0.5", "external_ip": "172.16.0.10", "external_mac": ["set", []], "logical_port": ["set", []]},
+			{"_uuid": ["uuid", "n4"], "type": "dnat", "logical_ip": "10.0.0.6", "external_ip": "172.16.0.11", "external_mac": ["set", []], "logical_port": ["set", []]},
+			{"_uuid": ["uuid", "n5"], "type": "dnat_and_snat", "logical_ip": "10.1.0.5", "external_ip": "172.16.0.10", "external_mac": ["set", []], "logical_port": ["set", []]}
+		]
+	}`)
+	e := newTestExporter(nb, nil)
+
+	// the snat rules sharing 172.16.0.1 are no conflict
+	want := map[string]float64{
+		"external_ip=172.16.0.10,logical_ip=10.0.0.5,logical_router_name=router-1,type=dnat_and_snat,uuid=n3": 1,
+		"external_ip=172.16.0.10,logical_ip=10.1.0.5,logical_router_name=router-2,type=dnat_and_snat,uuid=n5": 1,
+	}
+	if got := collectMetrics(t, e.setNatInfoMetric, metricNatDuplicateExternalIP); !reflect.DeepEqual(got, want) {
+		t.Errorf("duplicate external IPs = %v, want %v", got, want)
+	}
+
+	want = map[string]float64{
+		"logical_router_name=router-1,type=snat,uuid=r1":          2,
+		"logical_router_name=router-1,type=dnat,uuid=r1":          1,
+		"logical_router_name=router-1,type=dnat_and_snat,uuid=r1": 1,
+		"logical_router_name=router-2,type=snat,uuid=r2":          0,
+		"logical_router_name=router-2,type=dnat,uuid=r2":          0,
+		"logical_router_name=router-2,type=dnat_and_snat,uuid=r2": 1,
+	}
+	if got := collectMetrics(t, e.setNatInfoMetric, metricLogicalRouterNatTypeNum); !reflect.DeepEqual(got, want) {
+		t.Errorf("NAT rules per type = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,67 @@
+package ovnmonitor
+
+// OvnPortBinding holds a port binding of the southbound database, with the
+// chassis resolved to their names.
+type OvnPortBinding struct {
+	UUID        string
+	LogicalPort string
+	// Type is vif for the ports of VMs and containers, whose type is empty
+	// in the database.
+	Type             string
+	Datapath         string
+	Up               bool
+	Chassis          string
+	RequestedChassis string
+}
+
+// getPortBindings returns the port bindings of the southbound database.
+// The up and requested_chassis columns only exist in newer versions of OVN,
+// hasUp reports whether Up is known, RequestedChassis is empty without the
+// column.
+func (e *Exporter) getPortBindings() (bindings []*OvnPortBinding, hasUp bool, err error) {
+	hasUp, err = e.sbClient.HasColumn("Port_Binding", "up")
+	if err != nil {
+		e.countRequestError(e.sbClient.database, "get_port_bindings", err)
+		return nil, false, err
+	}
+
+	rows, err := e.selectSbRows("Chassis")
+	if err != nil {
+		return nil, false, err
+	}
+	chassisName := make(map[string]string, len(rows))
+	for _, row := range rows {
+		chassisName[row.String("_uuid")] = row.String("name")
+	}
+
+	rows, err = e.selectSbRows("Datapath_Binding")
+	if err != nil {
+		return nil, false, err
+	}
+	datapathName := make(map[string]string, len(rows))
+	for _, row := range rows {
+		datapathName[row.String("_uuid")] = row.Map("external_ids")["name"]
+	}
+
+	rows, err = e.selectSbRows("Port_Binding")
+	if err != nil {
+		return nil, false, err
+	}
+	bindings = make([]*OvnPortBinding, 0, len(rows))
+	for _, row := range rows {
+		pb := &OvnPortBinding{
+			UUID:             row.String("_uuid"),
+			LogicalPort:      row.String("logical_port"),
+			Type:             row.String("type"),
+			Datapath:         datapathName[row.String("datapath")],
+			Up:               row.Bool("up"),
+			Chassis:          chassisName[row.String("chassis")],
+			RequestedChassis: chassisName[row.String("requested_chassis")],
+		}
+		if pb.Type == "" {
+			pb.Type = "vif"
+		}
+		bindings = append(bindings, pb)
+	}
+	return bindings, hasUp, nil
+}
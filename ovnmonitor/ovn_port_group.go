@@ -0,0 +1,28 @@
+package ovnmonitor
+
+// OvnPortGroup holds a port group of the northbound database.
+type OvnPortGroup struct {
+	UUID  string
+	Name  string
+	Ports []string
+	ACLs  []string
+}
+
+// getPortGroups returns the port groups of the northbound database.
+func (e *Exporter) getPortGroups() ([]*OvnPortGroup, error) {
+	rows, err := e.nbClient.Select("Port_Group", "_uuid", "name", "ports", "acls")
+	if err != nil {
+		e.countRequestError(e.nbClient.database, "get_port_groups", err)
+		return nil, err
+	}
+	groups := make([]*OvnPortGroup, 0, len(rows))
+	for _, row := range rows {
+		groups = append(groups, &OvnPortGroup{
+			UUID:  row.String("_uuid"),
+			Name:  row.String("name"),
+			Ports: row.Strings("ports"),
+			ACLs:  row.Strings("acls"),
+		})
+	}
+	return groups, nil
+}
@@ -0,0 +1,110 @@
+package ovnmonitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OvsBridge holds a bridge of the local Open_vSwitch database along with
+// the interfaces of its ports.
+type OvsBridge struct {
+	UUID         string
+	Name         string
+	DatapathType string
+	PortsNum     int
+	Interfaces   []*OvsInterface
+}
+
+// OvsInterface holds an interface of the local Open_vSwitch database.
+// AdminState and LinkState are up or down, empty when not reported. Ofport
+// is -1 when ovs-vswitchd failed to add the interface.
+type OvsInterface struct {
+	UUID       string
+	Name       string
+	Type       string
+	AdminState string
+	LinkState  string
+	Error      string
+	Ofport     float64
+}
+
+// getVswitchdControlSocket returns the control socket of ovs-vswitchd,
+// either the configured one or the one found through its pid file.
+func (e *Exporter) getVswitchdControlSocket() (string, error) {
+	if e.vswitchdSocketControl != "" {
+		return e.vswitchdSocketControl, nil
+	}
+	pid, err := os.ReadFile(e.vswitchdFilePidPath)
+	if err != nil {
+		return "", fmt.Errorf("read ovs-vswitchd pid failed: %w", err)
+	}
+	// ovs-vswitchd creates its control socket next to its pid file
+	runDir := filepath.Dir(e.vswitchdFilePidPath)
+	return filepath.Join(runDir, "ovs-vswitchd."+strings.TrimSpace(string(pid))+".ctl"), nil
+}
+
+// getOvsBridges returns the bridges of the local Open_vSwitch database.
+func (e *Exporter) getOvsBridges() ([]*OvsBridge, error) {
+	rows, err := e.ovsClient.Select("Interface", "_uuid", "name", "type", "admin_state", "link_state", "error", "ofport")
+	if err != nil {
+		e.countRequestError(e.ovsClient.database, "get_ovs_bridges", err)
+		return nil, err
+	}
+	interfaces := make(map[string]*OvsInterface, len(rows))
+	for _, row := range rows {
+		iface := &OvsInterface{
+			UUID:       row.String("_uuid"),
+			Name:       row.String("name"),
+			Type:       row.String("type"),
+			AdminState: row.String("admin_state"),
+			LinkState:  row.String("link_state"),
+			Error:      row.String("error"),
+			Ofport:     row.Float("ofport"),
+		}
+		// the type defaults to a system interface when not set
+		if iface.Type == "" {
+			iface.Type = "system"
+		}
+		interfaces[iface.UUID] = iface
+	}
+
+	rows, err = e.ovsClient.Select("Port", "_uuid", "interfaces")
+	if err != nil {
+		e.countRequestError(e.ovsClient.database, "get_ovs_bridges", err)
+		return nil, err
+	}
+	portInterfaces := make(map[string][]string, len(rows))
+	for _, row := range rows {
+		portInterfaces[row.String("_uuid")] = row.Strings("interfaces")
+	}
+
+	rows, err = e.ovsClient.Select("Bridge", "_uuid", "name", "datapath_type", "ports")
+	if err != nil {
+		e.countRequestError(e.ovsClient.database, "get_ovs_bridges", err)
+		return nil, err
+	}
+	bridges := make([]*OvsBridge, 0, len(rows))
+	for _, row := range rows {
+		bridge := &OvsBridge{
+			UUID:         row.String("_uuid"),
+			Name:         row.String("name"),
+			DatapathType: row.String("datapath_type"),
+		}
+		if bridge.DatapathType == "" {
+			bridge.DatapathType = "system"
+		}
+		ports := row.Strings("ports")
+		bridge.PortsNum = len(ports)
+		for _, port := range ports {
+			for _, uuid := range portInterfaces[port] {
+				if iface, ok := interfaces[uuid]; ok {
+					bridge.Interfaces = append(bridge.Interfaces, iface)
+				}
+			}
+		}
+		bridges = append(bridges, bridge)
+	}
+	return bridges, nil
+}
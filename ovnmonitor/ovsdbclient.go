@@ -0,0 +1,417 @@
+package ovnmonitor
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ovsdbClient is a minimal read-only OVSDB (RFC 7047) client for a single
+// database. It supports unix, tcp and ssl remotes and connects lazily. When
+// several remotes are given, e.g. the members of a raft cluster, it fails
+// over to the next one when the connection is lost and, if leaderOnly is
+// set, only reads from the current cluster leader.
+type ovsdbClient struct {
+	sync.Mutex
+	database       string
+	remotes        []string
+	current        int
+	leaderOnly     bool
+	defaultSslPort int
+	tlsConfig      *tls.Config
+	timeout        time.Duration
+	rpc            *jsonrpcConn
+	// columns caches the columns of every table of the database schema of
+	// the current connection.
+	columns map[string]map[string]bool
+}
+
+// splitRemotes splits a comma-separated list of remotes.
+func splitRemotes(remotes string) []string {
+	var list []string
+	for _, remote := range strings.Split(remotes, ",") {
+		if remote = strings.TrimSpace(remote); remote != "" {
+			list = append(list, remote)
+		}
+	}
+	return list
+}
+
+func newOvsdbClient(database, remotes string, leaderOnly bool, defaultSslPort int, tlsConfig *tls.Config, timeout time.Duration) *ovsdbClient {
+	return &ovsdbClient{
+		database:       database,
+		remotes:        splitRemotes(remotes),
+		leaderOnly:     leaderOnly,
+		defaultSslPort: defaultSslPort,
+		tlsConfig:      tlsConfig,
+		timeout:        timeout,
+	}
+}
+
+// Connect connects to the remote, it does nothing if already connected.
+func (c *ovsdbClient) Connect() error {
+	c.Lock()
+	defer c.Unlock()
+	return c.connect()
+}
+
+// connect tries every remote once, starting with the current one, and
+// stays with the first that accepts the connection and, with leaderOnly,
+// is the leader.
+func (c *ovsdbClient) connect() error {
+	if c.rpc != nil {
+		return nil
+	}
+	if len(c.remotes) == 0 {
+		return fmt.Errorf("no remote configured for %s", c.database)
+	}
+	var errs []error
+	start := c.current
+	for i := range c.remotes {
+		idx := (start + i) % len(c.remotes)
+		remote := c.remotes[idx]
+		conn, err := dialRemote(remote, c.defaultSslPort, c.tlsConfig, c.timeout)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed connecting to %s via %s: %w", c.database, remote, err))
+			continue
+		}
+		c.rpc = newJSONRPCConn(conn)
+		c.current = idx
+		if c.leaderOnly {
+			if err := c.checkLeader(); err != nil {
+				errs = append(errs, fmt.Errorf("%s via %s: %w", c.database, remote, err))
+				c.close()
+				continue
+			}
+		}
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes the connection, the next request reconnects.
+func (c *ovsdbClient) Close() {
+	c.Lock()
+	defer c.Unlock()
+	c.close()
+}
+
+func (c *ovsdbClient) close() {
+	if c.rpc != nil {
+		c.rpc.Close()
+		c.rpc = nil
+	}
+	c.columns = nil
+}
+
+// failover drops the connection and moves on to the next remote.
+func (c *ovsdbClient) failover() {
+	c.close()
+	if len(c.remotes) > 1 {
+		c.current = (c.current + 1) % len(c.remotes)
+	}
+}
+
+// call runs a JSON-RPC method with a per call deadline. On a transport error
+// the client fails over, so the next call uses another remote.
+func (c *ovsdbClient) call(method string, params []any, result any) error {
+	return c.callTimeout(c.timeout, method, params, result)
+}
+
+// callTimeout is like call but with the given deadline.
+func (c *ovsdbClient) callTimeout(timeout time.Duration, method string, params []any, result any) error {
+	if err := c.connect(); err != nil {
+		return err
+	}
+	if err := c.rpc.conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		c.failover()
+		return err
+	}
+	err := c.rpc.call(method, params, result)
+	var rpcErr *jsonrpcError
+	if err != nil && !errors.As(err, &rpcErr) {
+		c.failover()
+	}
+	return err
+}
+
+// Ping connects if needed and checks the connection with an echo request.
+func (c *ovsdbClient) Ping() error {
+	c.Lock()
+	defer c.Unlock()
+	return c.call("echo", nil, nil)
+}
+
+// HasColumn reports whether the database schema has the column, to support
+// columns that only newer versions of OVN have.
+func (c *ovsdbClient) HasColumn(table, column string) (bool, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.columns == nil {
+		var schema struct {
+			Tables map[string]struct {
+				Columns map[string]json.RawMessage `json:"columns"`
+			} `json:"tables"`
+		}
+		if err := c.call("get_schema", []any{c.database}, &schema); err != nil {
+			return false, fmt.Errorf("%s: failed to get schema: %w", c.database, err)
+		}
+		columns := make(map[string]map[string]bool, len(schema.Tables))
+		for name, t := range schema.Tables {
+			columns[name] = make(map[string]bool, len(t.Columns))
+			for column := range t.Columns {
+				columns[name][column] = true
+			}
+		}
+		c.columns = columns
+	}
+	return c.columns[table][column], nil
+}
+
+// errNotLeader is returned in leader-only mode when the connected server is
+// not the leader of its cluster.
+var errNotLeader = errors.New("server is not the cluster leader")
+
+// checkLeader verifies through the _Server database that the connected
+// server is the leader of its cluster. Standalone and relay databases have
+// no leader and always pass.
+func (c *ovsdbClient) checkLeader() error {
+	rows, err := c.selectRows(c.timeout, "_Server", "Database", nil, "name", "model", "leader")
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if row.String("name") != c.database {
+			continue
+		}
+		if row.String("model") == "clustered" && !row.Bool("leader") {
+			return errNotLeader
+		}
+		return nil
+	}
+	return fmt.Errorf("database %s not served", c.database)
+}
+
+// errOvsdbOperation is returned when the server rejected an operation.
+var errOvsdbOperation = errors.New("ovsdb operation failed")
+
+// ovsdbOperationResult is the result of a single operation of a transaction.
+type ovsdbOperationResult struct {
+	Rows    []ovsdbRow `json:"rows"`
+	Error   string     `json:"error"`
+	Details string     `json:"details"`
+}
+
+// Select returns the given columns of all rows of table. In leader-only
+// mode the leadership is verified first and the client fails over to the
+// next remote when the server lost it.
+func (c *ovsdbClient) Select(table string, columns ...string) ([]ovsdbRow, error) {
+	return c.SelectWhere(table, nil, columns...)
+}
+
+// SelectWhere is like Select but only returns the rows matching all
+// conditions, each of the form [column, function, value], e.g.
+// []any{"type", "==", "chassisredirect"}.
+func (c *ovsdbClient) SelectWhere(table string, where [][]any, columns ...string) ([]ovsdbRow, error) {
+	return c.selectTimeout(c.timeout, table, where, columns...)
+}
+
+// SelectTimeout is like Select but with its own deadline, for tables that
+// are too large to be read within the regular one.
+func (c *ovsdbClient) SelectTimeout(timeout time.Duration, table string, columns ...string) ([]ovsdbRow, error) {
+	return c.selectTimeout(timeout, table, nil, columns...)
+}
+
+func (c *ovsdbClient) selectTimeout(timeout time.Duration, table string, where [][]any, columns ...string) ([]ovsdbRow, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.leaderOnly && c.rpc != nil {
+		if err := c.checkLeader(); errors.Is(err, errNotLeader) {
+			c.failover()
+		}
+	}
+	return c.selectRows(timeout, c.database, table, where, columns...)
+}
+
+func (c *ovsdbClient) selectRows(timeout time.Duration, database, table string, where [][]any, columns ...string) ([]ovsdbRow, error) {
+	if where == nil {
+		where = [][]any{}
+	}
+	op := map[string]any{
+		"op":      "select",
+		"table":   table,
+		"where":   where,
+		"columns": columns,
+	}
+	var results []ovsdbOperationResult
+	if err := c.callTimeout(timeout, "transact", []any{database, op}, &results); err != nil {
+		return nil, fmt.Errorf("%s: '%s' table error: %w", database, table, err)
+	}
+	if len(results) != 1 {
+		return nil, fmt.Errorf("%s: '%s' table error: unexpected number of results %d", database, table, len(results))
+	}
+	if results[0].Error != "" {
+		return nil, fmt.Errorf("%s: '%s' table error: %w: %s: %s", database, table, errOvsdbOperation, results[0].Error, results[0].Details)
+	}
+	return results[0].Rows, nil
+}
+
+// remoteAddress splits an OVSDB remote in the format used by the OVS tools,
+// unix:FILE, tcp:HOST:PORT or ssl:HOST:PORT, into its protocol and address.
+// ssl remotes without a port use defaultSslPort.
+func remoteAddress(remote string, defaultSslPort int) (proto, address string, err error) {
+	proto, address, ok := strings.Cut(remote, ":")
+	if !ok {
+		return "", "", fmt.Errorf("invalid remote %q", remote)
+	}
+	switch proto {
+	case "unix", "tcp":
+	case "ssl":
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(strings.Trim(address, "[]"), strconv.Itoa(defaultSslPort))
+		}
+	default:
+		return "", "", fmt.Errorf("unsupported remote %q", remote)
+	}
+	return proto, address, nil
+}
+
+// dialRemote connects to an OVSDB remote, see remoteAddress for its format.
+func dialRemote(remote string, defaultSslPort int, tlsConfig *tls.Config, timeout time.Duration) (net.Conn, error) {
+	proto, address, err := remoteAddress(remote, defaultSslPort)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	switch proto {
+	case "unix":
+		return dialer.Dial("unix", address)
+	case "ssl":
+		if tlsConfig == nil {
+			return nil, fmt.Errorf("remote %q requires --ssl.private-key, --ssl.certificate and --ssl.ca-cert", remote)
+		}
+		return tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	default:
+		return dialer.Dial("tcp", address)
+	}
+}
+
+// ovsdbRow is a table row as returned by the select operation, indexed by
+// column name. The accessors decode the OVSDB JSON notation of atoms, sets
+// and maps and return zero values for missing or malformed columns.
+type ovsdbRow map[string]json.RawMessage
+
+// decodeAtom returns the string form of an atom; uuids are returned as their
+// bare value.
+func decodeAtom(raw json.RawMessage) (string, bool) {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return "", false
+	}
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case []any:
+		if len(v) == 2 && (v[0] == "uuid" || v[0] == "named-uuid") {
+			s, ok := v[1].(string)
+			return s, ok
+		}
+	}
+	return "", false
+}
+
+// decodeTagged splits a ["set", ...] or ["map", ...] value into its tag and
+// elements.
+func decodeTagged(raw json.RawMessage) (string, []json.RawMessage) {
+	var tagged []json.RawMessage
+	if err := json.Unmarshal(raw, &tagged); err != nil || len(tagged) != 2 {
+		return "", nil
+	}
+	var tag string
+	if err := json.Unmarshal(tagged[0], &tag); err != nil {
+		return "", nil
+	}
+	var elems []json.RawMessage
+	if err := json.Unmarshal(tagged[1], &elems); err != nil {
+		return "", nil
+	}
+	return tag, elems
+}
+
+// Strings returns the elements of a set column. A scalar column is returned
+// as a set with a single element.
+func (r ovsdbRow) Strings(column string) []string {
+	raw, ok := r[column]
+	if !ok {
+		return nil
+	}
+	if tag, elems := decodeTagged(raw); tag == "set" {
+		values := make([]string, 0, len(elems))
+		for _, elem := range elems {
+			if s, ok := decodeAtom(elem); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	if s, ok := decodeAtom(raw); ok {
+		return []string{s}
+	}
+	return nil
+}
+
+// String returns the value of a scalar or optional column.
+func (r ovsdbRow) String(column string) string {
+	if values := r.Strings(column); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Float returns the value of a numeric column.
+func (r ovsdbRow) Float(column string) float64 {
+	value, _ := strconv.ParseFloat(r.String(column), 64)
+	return value
+}
+
+// Bool returns the value of a boolean column.
+func (r ovsdbRow) Bool(column string) bool {
+	return r.String(column) == "true"
+}
+
+// Map returns the value of a map column, keys and values in string form.
+func (r ovsdbRow) Map(column string) map[string]string {
+	m := make(map[string]string)
+	raw, ok := r[column]
+	if !ok {
+		return m
+	}
+	tag, elems := decodeTagged(raw)
+	if tag != "map" {
+		return m
+	}
+	for _, elem := range elems {
+		var pair []json.RawMessage
+		if err := json.Unmarshal(elem, &pair); err != nil || len(pair) != 2 {
+			continue
+		}
+		k, kok := decodeAtom(pair[0])
+		v, vok := decodeAtom(pair[1])
+		if kok && vok {
+			m[k] = v
+		}
+	}
+	return m
+}
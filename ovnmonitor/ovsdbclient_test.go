@@ -0,0 +1,472 @@
+package ovnmonitor
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serveOvsdb starts a fake ovsdb-server for database serving the select
+// operation and the schema from tables, which maps every table to its rows
+// in the OVSDB JSON notation, and returns its remote. A table without rows
+// accepts any column, otherwise only the columns of its rows.
+func serveOvsdb(t *testing.T, database, tables string) string {
+	t.Helper()
+	var rows map[string][]map[string]any
+	if err := json.Unmarshal([]byte(tables), &rows); err != nil {
+		t.Fatalf("invalid tables %s: %v", tables, err)
+	}
+	socket := filepath.Join(t.TempDir(), "db.sock")
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveOvsdbConn(conn, database, rows)
+		}
+	}()
+	return "unix:" + socket
+}
+
+// newTestOvsdbClient returns a client of a fake ovsdb-server, see serveOvsdb.
+func newTestOvsdbClient(t *testing.T, database, tables string) *ovsdbClient {
+	t.Helper()
+	c := newOvsdbClient(database, serveOvsdb(t, database, tables), false, 6641, nil, time.Second)
+	t.Cleanup(c.Close)
+	return c
+}
+
+func serveOvsdbConn(conn net.Conn, database string, tables map[string][]map[string]any) {
+	defer conn.Close()
+	columns := make(map[string]map[string]any, len(tables))
+	for table, rows := range tables {
+		columns[table] = make(map[string]any)
+		for _, row := range rows {
+			for column := range row {
+				columns[table][column] = map[string]any{"type": "string"}
+			}
+		}
+	}
+
+	dec, enc := json.NewDecoder(conn), json.NewEncoder(conn)
+	for {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+			ID     any               `json:"id"`
+		}
+		if err := dec.Decode(&req); err != nil {
+			return
+		}
+		resp := jsonrpcResponse{ID: req.ID}
+		switch req.Method {
+		case "echo":
+			resp.Result = []any{}
+		case "get_schema":
+			schema := make(map[string]any, len(columns))
+			for table, c := range columns {
+				schema[table] = map[string]any{"columns": c}
+			}
+			resp.Result = map[string]any{"name": database, "tables": schema}
+		case "transact":
+			resp.Result = []any{selectFakeRows(tables, columns, req.Params[1])}
+		default:
+			resp.Error = "unknown method"
+		}
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// selectFakeRows runs a select operation on tables. Only the == function is
+// supported in where clauses.
+func selectFakeRows(tables map[string][]map[string]any, columns map[string]map[string]any, raw json.RawMessage) map[string]any {
+	var op struct {
+		Table   string   `json:"table"`
+		Where   [][]any  `json:"where"`
+		Columns []string `json:"columns"`
+	}
+	if err := json.Unmarshal(raw, &op); err != nil {
+		return map[string]any{"error": "syntax error", "details": err.Error()}
+	}
+	rows, ok := tables[op.Table]
+	if !ok {
+		return map[string]any{"error": "unknown table", "details": "No table named " + op.Table + "."}
+	}
+	for _, column := range op.Columns {
+		// every table has _uuid, even when the rows of a test leave it out
+		if _, ok := columns[op.Table][column]; !ok && len(rows) > 0 && column != "_uuid" {
+			return map[string]any{"error": "unknown column", "details": "No column " + column + " in table " + op.Table + "."}
+		}
+	}
+	selected := []map[string]any{}
+	for _, row := range rows {
+		match := true
+		for _, cond := range op.Where {
+			if !reflect.DeepEqual(row[cond[0].(string)], cond[2]) {
+				match = false
+			}
+		}
+		if !match {
+			continue
+		}
+		r := make(map[string]any, len(op.Columns))
+		for _, column := range op.Columns {
+			if value, ok := row[column]; ok {
+				r[column] = value
+			}
+		}
+		selected = append(selected, r)
+	}
+	return map[string]any{"rows": selected}
+}
+
+// testRow decodes a row as the select operation returns it.
+func testRow(t *testing.T, row string) ovsdbRow {
+	t.Helper()
+	var r ovsdbRow
+	if err := json.Unmarshal([]byte(row), &r); err != nil {
+		t.Fatalf("invalid row %s: %v", row, err)
+	}
+	return r
+}
+
+func TestOvsdbRowStrings(t *testing.T) {
+	row := testRow(t, `{
+		"name": "sw0",
+		"ports": ["set", [["uuid", "2ab5e5b8-0d8a-4a70-9b3f-4c3f2b0d1c10"], ["uuid", "7f0c9a8e-1b2d-4c3e-8f9a-0b1c2d3e4f50"]]],
+		"chassis": ["uuid", "5a6b7c8d-9e0f-4a1b-8c2d-3e4f5a6b7c8d"],
+		"addresses": ["set", ["00:00:00:00:00:01 10.0.0.1", "00:00:00:00:00:02 10.0.0.2"]],
+		"tag": ["set", []],
+		"tunnel_key": 7,
+		"up": true
+	}`)
+	tests := []struct {
+		column string
+		want   []string
+	}{
+		{"name", []string{"sw0"}},
+		{"ports", []string{"2ab5e5b8-0d8a-4a70-9b3f-4c3f2b0d1c10", "7f0c9a8e-1b2d-4c3e-8f9a-0b1c2d3e4f50"}},
+		{"chassis", []string{"5a6b7c8d-9e0f-4a1b-8c2d-3e4f5a6b7c8d"}},
+		{"addresses", []string{"00:00:00:00:00:01 10.0.0.1", "00:00:00:00:00:02 10.0.0.2"}},
+		{"tag", []string{}},
+		{"tunnel_key", []string{"7"}},
+		{"up", []string{"true"}},
+		{"missing", nil},
+	}
+	for _, tt := range tests {
+		if got := row.Strings(tt.column); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Strings(%q) = %#v, want %#v", tt.column, got, tt.want)
+		}
+	}
+}
+
+func TestOvsdbRowScalars(t *testing.T) {
+	row := testRow(t, `{
+		"name": "sw0",
+		"chassis": ["uuid", "5a6b7c8d-9e0f-4a1b-8c2d-3e4f5a6b7c8d"],
+		"tag": ["set", [100]],
+		"ofport": ["set", [-1]],
+		"empty": ["set", []],
+		"nb_cfg": 1234567890123,
+		"up": ["set", [true]],
+		"enabled": false
+	}`)
+	tests := []struct {
+		column     string
+		wantString string
+		wantFloat  float64
+		wantBool   bool
+	}{
+		{"name", "sw0", 0, false},
+		{"chassis", "5a6b7c8d-9e0f-4a1b-8c2d-3e4f5a6b7c8d", 0, false},
+		{"tag", "100", 100, false},
+		{"ofport", "-1", -1, false},
+		{"empty", "", 0, false},
+		{"nb_cfg", "1234567890123", 1234567890123, false},
+		{"up", "true", 0, true},
+		{"enabled", "false", 0, false},
+		{"missing", "", 0, false},
+	}
+	for _, tt := range tests {
+		if got := row.String(tt.column); got != tt.wantString {
+			t.Errorf("String(%q) = %q, want %q", tt.column, got, tt.wantString)
+		}
+		if got := row.Float(tt.column); got != tt.wantFloat {
+			t.Errorf("Float(%q) = %v, want %v", tt.column, got, tt.wantFloat)
+		}
+		if got := row.Bool(tt.column); got != tt.wantBool {
+			t.Errorf("Bool(%q) = %v, want %v", tt.column, got, tt.wantBool)
+		}
+	}
+}
+
+func TestOvsdbRowMap(t *testing.T) {
+	row := testRow(t, `{
+		"external_ids": ["map", [["system-id", "chassis-1"], ["ovn-bridge", "br-int"]]],
+		"options": ["map", [["requested-chassis", "chassis-1"], ["mtu", 1442]]],
+		"ports": ["map", [[10, ["uuid", "2ab5e5b8-0d8a-4a70-9b3f-4c3f2b0d1c10"]]]],
+		"empty": ["map", []],
+		"name": "sw0",
+		"set": ["set", ["a", "b"]]
+	}`)
+	tests := []struct {
+		column string
+		want   map[string]string
+	}{
+		{"external_ids", map[string]string{"system-id": "chassis-1", "ovn-bridge": "br-int"}},
+		{"options", map[string]string{"requested-chassis": "chassis-1", "mtu": "1442"}},
+		{"ports", map[string]string{"10": "2ab5e5b8-0d8a-4a70-9b3f-4c3f2b0d1c10"}},
+		{"empty", map[string]string{}},
+		{"name", map[string]string{}},
+		{"set", map[string]string{}},
+		{"missing", map[string]string{}},
+	}
+	for _, tt := range tests {
+		if got := row.Map(tt.column); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Map(%q) = %#v, want %#v", tt.column, got, tt.want)
+		}
+	}
+}
+
+func TestOvsdbClientSelect(t *testing.T) {
+	c := newTestOvsdbClient(t, "OVN_Southbound", `{
+		"Chassis": [
+			{"_uuid": ["uuid", "5a6b7c8d-9e0f-4a1b-8c2d-3e4f5a6b7c8d"], "name": "chassis-1", "hostname": "node-1"},
+			{"_uuid": ["uuid", "6b7c8d9e-0f1a-4b2c-9d3e-4f5a6b7c8d9e"], "name": "chassis-2", "hostname": "node-2"}
+		],
+		"FDB": []
+	}`)
+
+	rows, err := c.Select("Chassis", "name", "hostname")
+	if err != nil {
+		t.Fatalf("Select() failed: %v", err)
+	}
+	var names []string
+	for _, row := range rows {
+		names = append(names, row.String("name")+"@"+row.String("hostname"))
+	}
+	if want := []string{"chassis-1@node-1", "chassis-2@node-2"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Select() = %v, want %v", names, want)
+	}
+
+	rows, err = c.SelectWhere("Chassis", [][]any{{"name", "==", "chassis-2"}}, "_uuid")
+	if err != nil {
+		t.Fatalf("SelectWhere() failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0].String("_uuid") != "6b7c8d9e-0f1a-4b2c-9d3e-4f5a6b7c8d9e" {
+		t.Errorf("SelectWhere() = %v, want chassis-2", rows)
+	}
+
+	if rows, err := c.Select("FDB", "dp_key"); err != nil || len(rows) != 0 {
+		t.Errorf("Select() of an empty table = %v, %v, want no rows", rows, err)
+	}
+	if _, err := c.Select("Chassis", "transport_zones"); !errors.Is(err, errOvsdbOperation) {
+		t.Errorf("Select() of an unknown column error = %v, want %v", err, errOvsdbOperation)
+	}
+	if _, err := c.Select("Chassis_Private", "name"); !errors.Is(err, errOvsdbOperation) {
+		t.Errorf("Select() of an unknown table error = %v, want %v", err, errOvsdbOperation)
+	}
+	// the connection survives failed operations
+	if err := c.Ping(); err != nil {
+		t.Errorf("Ping() failed: %v", err)
+	}
+}
+
+func TestRemoteAddress(t *testing.T) {
+	tests := []struct {
+		remote      string
+		wantProto   string
+		wantAddress string
+		wantErr     bool
+	}{
+		{remote: "unix:/var/run/ovn/ovnnb_db.sock", wantProto: "unix", wantAddress: "/var/run/ovn/ovnnb_db.sock"},
+		{remote: "tcp:10.0.0.1:6641", wantProto: "tcp", wantAddress: "10.0.0.1:6641"},
+		{remote: "ssl:10.0.0.1:16641", wantProto: "ssl", wantAddress: "10.0.0.1:16641"},
+		{remote: "ssl:10.0.0.1", wantProto: "ssl", wantAddress: "10.0.0.1:6641"},
+		{remote: "ssl:ovn-central", wantProto: "ssl", wantAddress: "ovn-central:6641"},
+		{remote: "ssl:[fd00::1]", wantProto: "ssl", wantAddress: "[fd00::1]:6641"},
+		{remote: "ssl:[fd00::1]:16641", wantProto: "ssl", wantAddress: "[fd00::1]:16641"},
+		{remote: "/var/run/ovn/ovnnb_db.sock", wantErr: true},
+		{remote: "udp:10.0.0.1:6641", wantErr: true},
+	}
+	for _, tt := range tests {
+		proto, address, err := remoteAddress(tt.remote, 6641)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("remoteAddress(%q) succeeded, want error", tt.remote)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("remoteAddress(%q) failed: %v", tt.remote, err)
+			continue
+		}
+		if proto != tt.wantProto || address != tt.wantAddress {
+			t.Errorf("remoteAddress(%q) = %q, %q, want %q, %q", tt.remote, proto, address, tt.wantProto, tt.wantAddress)
+		}
+	}
+}
+
+func TestDialRemote(t *testing.T) {
+	unixPath := filepath.Join(t.TempDir(), "ovnnb_db.sock")
+	unixListener, err := net.Listen("unix", unixPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unixListener.Close()
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tcpListener.Close()
+	_, tcpPort, _ := net.SplitHostPort(tcpListener.Addr().String())
+
+	tests := []struct {
+		remote  string
+		wantErr string
+	}{
+		{remote: "unix:" + unixPath},
+		{remote: "tcp:127.0.0.1:" + tcpPort},
+		{remote: "ssl:127.0.0.1:" + tcpPort, wantErr: "requires --ssl.private-key"},
+		{remote: "unix:" + filepath.Join(t.TempDir(), "missing.sock"), wantErr: "no such file"},
+		{remote: "127.0.0.1:" + tcpPort, wantErr: "unsupported remote"},
+		{remote: "ovnnb_db.sock", wantErr: "invalid remote"},
+	}
+	for _, tt := range tests {
+		conn, err := dialRemote(tt.remote, 6641, nil, time.Second)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("dialRemote(%q) failed: %v", tt.remote, err)
+				continue
+			}
+			conn.Close()
+			continue
+		}
+		if err == nil {
+			conn.Close()
+			t.Errorf("dialRemote(%q) succeeded, want error containing %q", tt.remote, tt.wantErr)
+		} else if !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("dialRemote(%q) error = %q, want it to contain %q", tt.remote, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOvsdbClientHasColumn(t *testing.T) {
+	c := newTestOvsdbClient(t, "OVN_Southbound", `{
+		"Port_Binding": [{"logical_port": "lsp1", "up": ["set", [true]]}]
+	}`)
+	tests := []struct {
+		table, column string
+		want          bool
+	}{
+		{"Port_Binding", "up", true},
+		{"Port_Binding", "requested_chassis", false},
+		{"FDB", "dp_key", false},
+	}
+	for _, tt := range tests {
+		got, err := c.HasColumn(tt.table, tt.column)
+		if err != nil || got != tt.want {
+			t.Errorf("HasColumn(%q, %q) = %v, %v, want %v", tt.table, tt.column, got, err, tt.want)
+		}
+	}
+}
+
+func TestSplitRemotes(t *testing.T) {
+	tests := []struct {
+		remotes string
+		want    []string
+	}{
+		{"unix:/var/run/ovn/ovnnb_db.sock", []string{"unix:/var/run/ovn/ovnnb_db.sock"}},
+		{"tcp:10.0.0.1:6641, tcp:10.0.0.2:6641,,", []string{"tcp:10.0.0.1:6641", "tcp:10.0.0.2:6641"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := splitRemotes(tt.remotes); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitRemotes(%q) = %#v, want %#v", tt.remotes, got, tt.want)
+		}
+	}
+}
+
+func TestOvsdbClientFailover(t *testing.T) {
+	tests := []struct {
+		remotes string
+		current int
+		want    int
+	}{
+		{"tcp:10.0.0.1:6641", 0, 0},
+		{"tcp:10.0.0.1:6641,tcp:10.0.0.2:6641,tcp:10.0.0.3:6641", 0, 1},
+		{"tcp:10.0.0.1:6641,tcp:10.0.0.2:6641,tcp:10.0.0.3:6641", 1, 2},
+		{"tcp:10.0.0.1:6641,tcp:10.0.0.2:6641,tcp:10.0.0.3:6641", 2, 0},
+	}
+	for _, tt := range tests {
+		c := newOvsdbClient("OVN_Northbound", tt.remotes, false, 6641, nil, time.Second)
+		c.current = tt.current
+		local, remote := net.Pipe()
+		c.rpc = newJSONRPCConn(local)
+		c.columns = map[string]map[string]bool{"Logical_Switch": {"name": true}}
+
+		c.failover()
+		remote.Close()
+		if c.current != tt.want {
+			t.Errorf("failover of %q from %d moved to %d, want %d", tt.remotes, tt.current, c.current, tt.want)
+		}
+		if c.rpc != nil || c.columns != nil {
+			t.Errorf("failover of %q kept the connection or the schema columns", tt.remotes)
+		}
+		if _, err := local.Write([]byte("{}")); err == nil {
+			t.Errorf("failover of %q did not close the connection", tt.remotes)
+		}
+	}
+}
+
+func TestOvsdbClientLeaderOnly(t *testing.T) {
+	follower := serveOvsdb(t, "OVN_Northbound", `{
+		"Database": [{"name": "OVN_Northbound", "model": "clustered", "leader": false}],
+		"Logical_Switch": [{"name": "on-follower"}]
+	}`)
+	leader := serveOvsdb(t, "OVN_Northbound", `{
+		"Database": [{"name": "OVN_Northbound", "model": "clustered", "leader": true}],
+		"Logical_Switch": [{"name": "on-leader"}]
+	}`)
+	down := "unix:" + filepath.Join(t.TempDir(), "down.sock")
+
+	tests := []struct {
+		name       string
+		leaderOnly bool
+		want       string
+	}{
+		{"leader only", true, "on-leader"},
+		{"any server", false, "on-follower"},
+	}
+	for _, tt := range tests {
+		c := newOvsdbClient("OVN_Northbound", strings.Join([]string{down, follower, leader}, ","), tt.leaderOnly, 6641, nil, time.Second)
+		rows, err := c.Select("Logical_Switch", "name")
+		c.Close()
+		if err != nil {
+			t.Errorf("%s: Select() failed: %v", tt.name, err)
+			continue
+		}
+		if len(rows) != 1 || rows[0].String("name") != tt.want {
+			t.Errorf("%s: Select() = %v, want the rows of %s", tt.name, rows, tt.want)
+		}
+	}
+
+	c := newOvsdbClient("OVN_Northbound", strings.Join([]string{down, follower}, ","), true, 6641, nil, time.Second)
+	defer c.Close()
+	if _, err := c.Select("Logical_Switch", "name"); !errors.Is(err, errNotLeader) {
+		t.Errorf("Select() without a leader error = %v, want %v", err, errNotLeader)
+	}
+}
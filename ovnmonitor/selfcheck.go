@@ -0,0 +1,155 @@
+package ovnmonitor
+
+import (
+	"log/slog"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// selfCheckTarget is a configured socket or file the exporter depends on.
+// Remotes of a database carry the client so they are dialed the way the
+// client dials them.
+type selfCheckTarget struct {
+	name   string
+	path   string
+	socket bool
+	client *ovsdbClient
+}
+
+// SelfCheck reports which of the configured sockets and files exist and
+// which of them can be reached. It only logs, a missing target is not fatal
+// since the OVN daemons may come up after the exporter.
+func (e *Exporter) SelfCheck() {
+	var targets []selfCheckTarget
+	if e.mode == modeController {
+		targets = e.controllerSelfCheckTargets()
+	} else {
+		targets = e.centralSelfCheckTargets()
+	}
+
+	for _, t := range targets {
+		exists, reachable, err := e.checkTarget(t)
+		if exists && reachable {
+			slog.Info("self-check passed", "target", t.name, "path", t.path)
+			continue
+		}
+		slog.Warn("self-check failed", "target", t.name, "path", t.path, "exists", exists, "reachable", reachable, "error", err)
+	}
+}
+
+func (e *Exporter) centralSelfCheckTargets() []selfCheckTarget {
+	var targets []selfCheckTarget
+	for _, remote := range e.nbClient.remotes {
+		targets = append(targets, selfCheckTarget{name: "northbound remote", path: remote, socket: true, client: e.nbClient})
+	}
+	targets = append(targets,
+		selfCheckTarget{name: "northbound control socket", path: e.nbSocketControl, socket: true},
+		selfCheckTarget{name: "northbound database file", path: e.nbFileDataPath},
+	)
+	for _, remote := range e.sbClient.remotes {
+		targets = append(targets, selfCheckTarget{name: "southbound remote", path: remote, socket: true, client: e.sbClient})
+	}
+	targets = append(targets,
+		selfCheckTarget{name: "southbound control socket", path: e.sbSocketControl, socket: true},
+		selfCheckTarget{name: "southbound database file", path: e.sbFileDataPath},
+	)
+	ics := []struct {
+		client       *ovsdbClient
+		socket, file string
+	}{
+		{e.icnbClient, e.icnbSocketControl, e.icnbFileDataPath},
+		{e.icsbClient, e.icsbSocketControl, e.icsbFileDataPath},
+	}
+	for _, ic := range ics {
+		if ic.client == nil {
+			continue
+		}
+		for _, remote := range ic.client.remotes {
+			targets = append(targets, selfCheckTarget{name: ic.client.database + " remote", path: remote, socket: true, client: ic.client})
+		}
+		if ic.socket != "" {
+			targets = append(targets, selfCheckTarget{name: ic.client.database + " control socket", path: ic.socket, socket: true})
+		}
+		if ic.file != "" {
+			targets = append(targets, selfCheckTarget{name: ic.client.database + " database file", path: ic.file})
+		}
+	}
+	if e.northdSocketControl != "" {
+		targets = append(targets, selfCheckTarget{name: "northd control socket", path: e.northdSocketControl, socket: true})
+	} else {
+		targets = append(targets, selfCheckTarget{name: "northd pid file", path: e.northdFilePidPath})
+		if socket, err := e.getNorthdControlSocket(); err == nil {
+			targets = append(targets, selfCheckTarget{name: "northd control socket", path: socket, socket: true})
+		}
+	}
+	return targets
+}
+
+func (e *Exporter) controllerSelfCheckTargets() []selfCheckTarget {
+	var targets []selfCheckTarget
+	if e.usesVswitchDatabase() {
+		for _, remote := range e.ovsClient.remotes {
+			targets = append(targets, selfCheckTarget{name: "vswitch remote", path: remote, socket: true, client: e.ovsClient})
+		}
+	}
+	if e.controllerSocketControl == "" {
+		targets = append(targets, selfCheckTarget{name: "ovn-controller pid file", path: e.controllerFilePidPath})
+	}
+	if socket, err := e.getControllerControlSocket(); err == nil {
+		targets = append(targets, selfCheckTarget{name: "ovn-controller control socket", path: socket, socket: true})
+	}
+	if e.collectorEnabled("ovs") {
+		if socket, err := e.getVswitchdControlSocket(); err == nil {
+			targets = append(targets, selfCheckTarget{name: "ovs-vswitchd control socket", path: socket, socket: true})
+		} else {
+			targets = append(targets, selfCheckTarget{name: "ovs-vswitchd pid file", path: e.vswitchdFilePidPath})
+		}
+	}
+	return targets
+}
+
+func (e *Exporter) checkTarget(t selfCheckTarget) (exists, reachable bool, err error) {
+	timeout := time.Duration(e.timeout) * time.Second
+	if t.client != nil {
+		proto, address, err := remoteAddress(t.path, t.client.defaultSslPort)
+		if err != nil {
+			return false, false, err
+		}
+		// a network remote has nothing to stat, it only can be dialed
+		if proto == "unix" {
+			if _, err := os.Stat(address); err != nil {
+				return false, false, err
+			}
+		}
+		conn, err := dialRemote(t.path, t.client.defaultSslPort, t.client.tlsConfig, timeout)
+		if err != nil {
+			return true, false, err
+		}
+		conn.Close()
+		return true, true, nil
+	}
+
+	path := strings.TrimPrefix(t.path, "unix:")
+	if _, err := os.Stat(path); err != nil {
+		return false, false, err
+	}
+	exists = true
+
+	if !t.socket {
+		f, err := os.Open(path)
+		if err != nil {
+			return exists, false, err
+		}
+		f.Close()
+		return exists, true, nil
+	}
+
+	conn, err := net.DialTimeout("unix", path, timeout)
+	if err != nil {
+		return exists, false, err
+	}
+	conn.Close()
+	return exists, true, nil
+}
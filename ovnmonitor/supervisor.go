@@ -0,0 +1,70 @@
+package ovnmonitor
+
+import (
+	"log/slog"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// supervisorCheckInterval is the interval between liveness checks of an
+	// established connection.
+	supervisorCheckInterval = 5 * time.Second
+	// supervisorMinBackoff and supervisorMaxBackoff bound the exponential
+	// backoff between reconnect attempts.
+	supervisorMinBackoff = 1 * time.Second
+	supervisorMaxBackoff = 60 * time.Second
+)
+
+// connectionSupervisor keeps the connection of an ovsdbClient alive. It
+// probes the connection periodically and reconnects with exponential
+// backoff and jitter when the connection is lost or cannot be established,
+// e.g. because the database socket disappeared.
+type connectionSupervisor struct {
+	client     *ovsdbClient
+	connected  atomic.Bool
+	reconnects atomic.Uint64
+}
+
+func newConnectionSupervisor(client *ovsdbClient) *connectionSupervisor {
+	return &connectionSupervisor{client: client}
+}
+
+// run supervises the connection until the process exits.
+func (s *connectionSupervisor) run() {
+	backoff := supervisorMinBackoff
+	everConnected := false
+	for {
+		err := s.client.Ping()
+		if err == nil {
+			if !s.connected.Load() {
+				if everConnected {
+					s.reconnects.Add(1)
+					slog.Info("reconnected to database", "database", s.client.database)
+				} else {
+					slog.Info("connected to database", "database", s.client.database)
+				}
+			}
+			everConnected = true
+			s.connected.Store(true)
+			backoff = supervisorMinBackoff
+			time.Sleep(supervisorCheckInterval)
+			continue
+		}
+
+		if s.connected.Swap(false) {
+			slog.Error("lost connection to database", "database", s.client.database, "error", err)
+		}
+		wait := jitter(backoff)
+		slog.Warn("failed to connect to database, retrying", "database", s.client.database, "retry_in", wait, "error", err)
+		time.Sleep(wait)
+		backoff = min(2*backoff, supervisorMaxBackoff)
+	}
+}
+
+// jitter returns a random duration between d/2 and d, so that exporters do
+// not reconnect in lockstep after a database restart.
+func jitter(d time.Duration) time.Duration {
+	return d/2 + rand.N(d/2+1)
+}
@@ -0,0 +1,93 @@
+package ovnmonitor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// Classes of unixctl failures, use errors.Is to test a returned error.
+var (
+	// ErrUnixctlConnect means the control socket could not be reached.
+	ErrUnixctlConnect = errors.New("unixctl connect failed")
+	// ErrUnixctlTimeout means the daemon did not answer within the timeout.
+	ErrUnixctlTimeout = errors.New("unixctl timeout")
+	// ErrUnixctlCommand means the daemon rejected or failed the command.
+	ErrUnixctlCommand = errors.New("unixctl command failed")
+	// ErrUnixctlProtocol means the reply was not a valid unixctl response.
+	ErrUnixctlProtocol = errors.New("unixctl protocol error")
+)
+
+// UnixctlError is returned by the unixctl client for every failed call.
+type UnixctlError struct {
+	Socket  string
+	Command string
+	// Class is one of the ErrUnixctl* errors.
+	Class error
+	Err   error
+}
+
+func (e *UnixctlError) Error() string {
+	return fmt.Sprintf("%s: '%s' via %s: %v", e.Class, e.Command, e.Socket, e.Err)
+}
+
+// Unwrap makes both the failure class and the underlying error available to
+// errors.Is and errors.As.
+func (e *UnixctlError) Unwrap() []error {
+	return []error{e.Class, e.Err}
+}
+
+// unixctlClient runs commands against the control socket of an OVS or OVN
+// daemon, the same way ovs-appctl and ovn-appctl do.
+type unixctlClient struct {
+	timeout time.Duration
+}
+
+func newUnixctlClient(timeout time.Duration) *unixctlClient {
+	return &unixctlClient{timeout: timeout}
+}
+
+// call runs command with args on the daemon listening on socket and returns
+// its textual reply. The whole call, connect included, is bounded by the
+// client timeout.
+func (c *unixctlClient) call(socket, command string, args ...string) (string, error) {
+	socket = strings.TrimPrefix(socket, "unix:")
+	fail := func(class, err error) (string, error) {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			class = ErrUnixctlTimeout
+		}
+		return "", &UnixctlError{Socket: socket, Command: command, Class: class, Err: err}
+	}
+
+	deadline := time.Now().Add(c.timeout)
+	conn, err := net.DialTimeout("unix", socket, c.timeout)
+	if err != nil {
+		return fail(ErrUnixctlConnect, err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return fail(ErrUnixctlConnect, err)
+	}
+
+	params := make([]any, 0, len(args))
+	for _, arg := range args {
+		params = append(params, arg)
+	}
+	var reply string
+	if err := newJSONRPCConn(conn).call(command, params, &reply); err != nil {
+		var rpcErr *jsonrpcError
+		if errors.As(err, &rpcErr) {
+			return fail(ErrUnixctlCommand, errors.New(strings.TrimSpace(rpcErr.Message)))
+		}
+		var opErr *net.OpError
+		if errors.As(err, &opErr) || errors.Is(err, io.EOF) {
+			return fail(ErrUnixctlConnect, err)
+		}
+		return fail(ErrUnixctlProtocol, err)
+	}
+	return reply, nil
+}
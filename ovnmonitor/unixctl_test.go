@@ -0,0 +1,146 @@
+package ovnmonitor
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// unixctlReply is the reply of a fake daemon to a command, either its output
+// or, when err is set, the error it reports.
+type unixctlReply struct {
+	output string
+	err    string
+}
+
+// serveUnixctl starts a fake daemon answering the unixctl commands in
+// replies, keyed by the command and its arguments separated by spaces, and
+// returns its control socket. Unknown commands are rejected like the OVS
+// daemons do.
+func serveUnixctl(t *testing.T, replies map[string]unixctlReply) string {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "daemon.ctl")
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				var req struct {
+					Method string   `json:"method"`
+					Params []string `json:"params"`
+					ID     any      `json:"id"`
+				}
+				if err := json.NewDecoder(conn).Decode(&req); err != nil {
+					return
+				}
+				resp := jsonrpcResponse{ID: req.ID}
+				reply, ok := replies[strings.Join(append([]string{req.Method}, req.Params...), " ")]
+				switch {
+				case !ok:
+					resp.Error = "\"" + req.Method + "\" is not a valid command\n"
+				case reply.err != "":
+					resp.Error = reply.err
+				default:
+					resp.Result = reply.output
+				}
+				_ = json.NewEncoder(conn).Encode(resp)
+			}()
+		}
+	}()
+	return socket
+}
+
+func TestUnixctlCall(t *testing.T) {
+	socket := serveUnixctl(t, map[string]unixctlReply{
+		"cluster/status OVN_Northbound": {output: "f1a5\nName: OVN_Northbound\n"},
+		"memory/show":                   {output: "cells:18410 monitors:6 sessions:4\n"},
+		"cluster/status OVN_Southbound": {err: "OVN_Southbound: not a clustered database\n"},
+	})
+	c := newUnixctlClient(time.Second)
+
+	tests := []struct {
+		socket    string
+		command   string
+		args      []string
+		want      string
+		wantClass error
+	}{
+		{socket: socket, command: "cluster/status", args: []string{"OVN_Northbound"}, want: "f1a5\nName: OVN_Northbound\n"},
+		{socket: "unix:" + socket, command: "memory/show", want: "cells:18410 monitors:6 sessions:4\n"},
+		{socket: socket, command: "cluster/status", args: []string{"OVN_Southbound"}, wantClass: ErrUnixctlCommand},
+		{socket: socket, command: "inc-engine/show-stats", wantClass: ErrUnixctlCommand},
+		{socket: filepath.Join(t.TempDir(), "missing.ctl"), command: "memory/show", wantClass: ErrUnixctlConnect},
+	}
+	for _, tt := range tests {
+		got, err := c.call(tt.socket, tt.command, tt.args...)
+		if tt.wantClass == nil {
+			if err != nil || got != tt.want {
+				t.Errorf("call(%q, %q, %q) = %q, %v, want %q", tt.socket, tt.command, tt.args, got, err, tt.want)
+			}
+			continue
+		}
+		var unixctlErr *UnixctlError
+		if !errors.Is(err, tt.wantClass) || !errors.As(err, &unixctlErr) {
+			t.Errorf("call(%q, %q, %q) error = %v, want %v", tt.socket, tt.command, tt.args, err, tt.wantClass)
+			continue
+		}
+		if unixctlErr.Command != tt.command || unixctlErr.Socket != strings.TrimPrefix(tt.socket, "unix:") {
+			t.Errorf("call(%q, %q, %q) error = %+v, want the command and socket", tt.socket, tt.command, tt.args, unixctlErr)
+		}
+	}
+}
+
+func TestUnixctlCallCommandError(t *testing.T) {
+	socket := serveUnixctl(t, map[string]unixctlReply{
+		"cluster/status OVN_Southbound": {err: "OVN_Southbound: not a clustered database\n"},
+	})
+	_, err := newUnixctlClient(time.Second).call(socket, "cluster/status", "OVN_Southbound")
+	var unixctlErr *UnixctlError
+	if !errors.As(err, &unixctlErr) {
+		t.Fatalf("call() error = %v, want a UnixctlError", err)
+	}
+	// the trailing newline of the daemon is dropped
+	if want := errors.New("OVN_Southbound: not a clustered database"); !reflect.DeepEqual(unixctlErr.Err, want) {
+		t.Errorf("call() error = %q, want %q", unixctlErr.Err, want)
+	}
+}
+
+func TestUnixctlCallTimeout(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "daemon.ctl")
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	// accept the connection but never answer
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		time.Sleep(time.Second)
+	}()
+
+	start := time.Now()
+	_, err = newUnixctlClient(100*time.Millisecond).call(socket, "coverage/show")
+	if !errors.Is(err, ErrUnixctlTimeout) {
+		t.Errorf("call() error = %v, want %v", err, ErrUnixctlTimeout)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("call() returned after %v, want it bounded by the timeout", elapsed)
+	}
+}
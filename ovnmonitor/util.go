@@ -1,54 +1,129 @@
 package ovnmonitor
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net"
 	"os"
-	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
-	"sync/atomic"
+	"syscall"
+	"time"
 
-	"github.com/kubeovn/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-// IncrementErrorCounter increases the counter of failed queries to OVN server.
-func (e *Exporter) IncrementErrorCounter() {
-	e.errorsLocker.Lock()
-	defer e.errorsLocker.Unlock()
-	atomic.AddInt64(&e.errors, 1)
+// requestErrorKey identifies a series of ovn_failed_req_count.
+type requestErrorKey struct {
+	database  string
+	operation string
+	class     string
+}
+
+// countRequestError counts a failed request to the OVN stack. database is
+// empty for requests that do not target a database, e.g. to ovn-northd.
+func (e *Exporter) countRequestError(database, operation string, err error) {
+	e.requestErrorsLocker.Lock()
+	defer e.requestErrorsLocker.Unlock()
+	e.requestErrors[requestErrorKey{database: database, operation: operation, class: requestErrorClass(err)}]++
+}
+
+// requestErrorClass classifies a failed request for the error_class label.
+func requestErrorClass(err error) string {
+	var netErr net.Error
+	var rpcErr *jsonrpcError
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, ErrUnixctlTimeout), errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return "connection_refused"
+	case errors.Is(err, ErrUnixctlConnect), errors.Is(err, syscall.ENOENT), errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.EPIPE), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.As(err, &netErr):
+		return "connection_error"
+	case errors.Is(err, ErrUnixctlCommand), errors.Is(err, errOvsdbOperation), errors.Is(err, errNotLeader), errors.As(err, &rpcErr):
+		return "command_error"
+	case errors.Is(err, ErrUnixctlProtocol), errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return "parse_error"
+	default:
+		return "other"
+	}
 }
 
 func (e *Exporter) getNorthdControlSocket() (string, error) {
 	if e.northdSocketControl != "" {
 		return e.northdSocketControl, nil
 	} else {
-		pid, err := os.ReadFile(e.Client.Service.Northd.File.Pid.Path)
+		pid, err := os.ReadFile(e.northdFilePidPath)
 		if err != nil {
 			return "", fmt.Errorf("read ovn-northd pid failed: %w", err)
 		}
-		return fmt.Sprintf("/var/run/ovn/ovn-northd." + strings.Trim(string(pid), "\n") + ".ctl"), nil
+		// ovn-northd creates its control socket next to its pid file
+		runDir := filepath.Dir(e.northdFilePidPath)
+		return filepath.Join(runDir, "ovn-northd."+strings.TrimSpace(string(pid))+".ctl"), nil
+	}
+}
+
+// clusterRoleValue maps a raft role to the value reported by ovn_status.
+func clusterRoleValue(role string) int {
+	switch role {
+	case "leader":
+		return 3
+	case "candidate":
+		return 2
+	case "follower":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// getDBRole returns the ovn_status value of a database. cluster/status only
+// works on a clustered database, a standalone one is the only server and so
+// reported as active when its storage is ok.
+func (e *Exporter) getDBRole(socket, file, dbName string) (int, error) {
+	clustered, err := getClusterEnableState(file)
+	if err != nil {
+		return 0, err
+	}
+	if !clustered {
+		ok, err := e.getDBStatus(socket, dbName)
+		if err != nil || !ok {
+			return 0, err
+		}
+		return 3, nil
+	}
+	status, err := e.getClusterInfo(socket, dbName)
+	if err != nil {
+		return 0, err
 	}
+	return clusterRoleValue(status.role), nil
 }
 
 func (e *Exporter) getOvnStatus() map[string]int {
 	result := make(map[string]int)
 
 	// get ovn-northbound status
-	output, err := e.Client.GetAppClusteringInfo("ovsdb-server-northbound")
-	if err != nil {
+	if role, err := e.getDBRole(e.nbSocketControl, e.nbFileDataPath, "OVN_Northbound"); err != nil {
 		slog.Error("get ovn-northbound status failed", "error", err)
 		result["ovsdb-server-northbound"] = 0
+	} else {
+		result["ovsdb-server-northbound"] = role
 	}
-	result["ovsdb-server-northbound"] = output.Role
 
 	// get ovn-southbound status
-	output, err = e.Client.GetAppClusteringInfo("ovsdb-server-southbound")
-	if err != nil {
+	if role, err := e.getDBRole(e.sbSocketControl, e.sbFileDataPath, "OVN_Southbound"); err != nil {
 		slog.Error("get ovn-southbound status failed", "error", err)
 		result["ovsdb-server-southbound"] = 0
+	} else {
+		result["ovsdb-server-southbound"] = role
 	}
-	result["ovsdb-server-southbound"] = output.Role
 
 	// get ovn-northd status
 	northdControlSocket, err := e.getNorthdControlSocket()
@@ -56,17 +131,16 @@ func (e *Exporter) getOvnStatus() map[string]int {
 		slog.Error("failed to get northd control socket", "error", err)
 		result["ovn-northd"] = 0
 	} else {
-		cmdstr := fmt.Sprintf("ovn-appctl -t %s status", northdControlSocket)
-		cmd := exec.Command("sh", "-c", cmdstr)
-		output, err := cmd.CombinedOutput()
+		output, err := e.appctl.call(northdControlSocket, "status")
 		if err != nil {
 			slog.Error("get ovn-northd status failed", "error", err)
+			e.countRequestError("", "appctl_northd_status", err)
 			result["ovn-northd"] = 0
 		}
-		if len(strings.Split(string(output), ":")) != 2 {
+		if len(strings.Split(output, ":")) != 2 {
 			result["ovn-northd"] = 0
 		} else {
-			status := strings.TrimSpace(strings.Split(string(output), ":")[1])
+			status := strings.TrimSpace(strings.Split(output, ":")[1])
 			if status == "standby" {
 				result["ovn-northd"] = 1
 			} else if status == "active" {
@@ -78,72 +152,701 @@ func (e *Exporter) getOvnStatus() map[string]int {
 	return result
 }
 
+// setICInfoMetric exports the tables of the IC databases that are
+// configured, nothing when ovn-ic is not used.
+func (e *Exporter) setICInfoMetric(ch chan<- prometheus.Metric) error {
+	if e.icnbClient != nil {
+		switches, err := e.getTransitSwitches()
+		if err != nil {
+			return err
+		}
+		for _, ts := range switches {
+			ch <- prometheus.MustNewConstMetric(metricICTransitSwitchInfo, prometheus.GaugeValue, 1, ts.UUID, ts.Name)
+		}
+	}
+
+	if e.icsbClient != nil {
+		zones, err := e.getAvailabilityZones()
+		if err != nil {
+			return err
+		}
+		for _, az := range zones {
+			ch <- prometheus.MustNewConstMetric(metricICAvailabilityZoneInfo, prometheus.GaugeValue, 1, az.UUID, az.Name)
+			ch <- prometheus.MustNewConstMetric(metricICGatewaysNum, prometheus.GaugeValue, float64(len(az.Gateways)), az.UUID, az.Name)
+			for _, gw := range az.Gateways {
+				ch <- prometheus.MustNewConstMetric(metricICGatewayInfo, prometheus.GaugeValue, 1, az.Name, gw.Name, gw.Hostname)
+			}
+			for origin, n := range az.RoutesNum {
+				ch <- prometheus.MustNewConstMetric(metricICRoutesNum, prometheus.GaugeValue, float64(n), az.UUID, az.Name, origin)
+			}
+			for ts, n := range az.PortBindingsNum {
+				ch <- prometheus.MustNewConstMetric(metricICPortBindingsNum, prometheus.GaugeValue, float64(n), az.UUID, az.Name, ts)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) setDBServerStatsMetric(ch chan<- prometheus.Metric, socket, dbName string) error {
+	var errs []error
+	if output, err := e.appctl.call(socket, "memory/show"); err != nil {
+		e.countRequestError(dbName, "appctl_memory", err)
+		errs = append(errs, err)
+	} else {
+		for item, v := range parseMemory(output) {
+			ch <- prometheus.MustNewConstMetric(metricDBMemory, prometheus.GaugeValue, v, dbName, item)
+		}
+	}
+
+	if output, err := e.appctl.call(socket, "coverage/show"); err != nil {
+		e.countRequestError(dbName, "appctl_coverage", err)
+		errs = append(errs, err)
+	} else {
+		for event, v := range parseCoverage(output) {
+			ch <- prometheus.MustNewConstMetric(metricDBCoverage, prometheus.CounterValue, v, dbName, event)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (e *Exporter) setNorthdStatsMetric(ch chan<- prometheus.Metric) error {
+	socket, err := e.getNorthdControlSocket()
+	if err != nil {
+		return fmt.Errorf("failed to get northd control socket: %w", err)
+	}
+
+	var errs []error
+	if output, err := e.appctl.call(socket, "inc-engine/show-stats"); err != nil {
+		e.countRequestError("", "appctl_northd_inc_engine_stats", err)
+		errs = append(errs, err)
+	} else {
+		for node, stats := range parseIncEngineStats(output) {
+			for t, v := range stats {
+				ch <- prometheus.MustNewConstMetric(metricNorthdIncEngineRuns, prometheus.CounterValue, v, node, t)
+			}
+		}
+	}
+
+	if output, err := e.appctl.call(socket, "stopwatch/show"); err != nil {
+		e.countRequestError("", "appctl_northd_stopwatch", err)
+		errs = append(errs, err)
+	} else {
+		for name, sw := range parseStopwatches(output) {
+			ch <- prometheus.MustNewConstMetric(metricNorthdStopwatchSamples, prometheus.CounterValue, sw.samples, name)
+			for stat, v := range sw.durations {
+				ch <- prometheus.MustNewConstMetric(metricNorthdStopwatch, prometheus.GaugeValue, v, name, stat)
+			}
+		}
+	}
+
+	if output, err := e.appctl.call(socket, "coverage/show"); err != nil {
+		e.countRequestError("", "appctl_northd_coverage", err)
+		errs = append(errs, err)
+	} else {
+		for event, v := range parseCoverage(output) {
+			ch <- prometheus.MustNewConstMetric(metricNorthdCoverage, prometheus.CounterValue, v, event)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (e *Exporter) setControllerInfoMetric(ch chan<- prometheus.Metric) error {
+	chassis, err := e.getControllerChassis()
+	if err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(metricControllerInfo, prometheus.GaugeValue, 1, chassis.Name, chassis.Bridge)
+	if chassis.NbCfg >= 0 {
+		ch <- prometheus.MustNewConstMetric(metricControllerNbCfg, prometheus.GaugeValue, chassis.NbCfg)
+	}
+
+	socket, err := e.getControllerControlSocket()
+	if err != nil {
+		return fmt.Errorf("failed to get ovn-controller control socket: %w", err)
+	}
+	output, err := e.appctl.call(socket, "connection-status")
+	if err != nil {
+		e.countRequestError("", "appctl_controller_connection_status", err)
+		return err
+	}
+	connected := 0.0
+	if strings.TrimSpace(output) == "connected" {
+		connected = 1
+	}
+	ch <- prometheus.MustNewConstMetric(metricControllerSBConnected, prometheus.GaugeValue, connected)
+	return nil
+}
+
+func (e *Exporter) setControllerStatsMetric(ch chan<- prometheus.Metric) error {
+	socket, err := e.getControllerControlSocket()
+	if err != nil {
+		return fmt.Errorf("failed to get ovn-controller control socket: %w", err)
+	}
+
+	var errs []error
+	if output, err := e.appctl.call(socket, "inc-engine/show-stats"); err != nil {
+		e.countRequestError("", "appctl_controller_inc_engine_stats", err)
+		errs = append(errs, err)
+	} else {
+		for node, stats := range parseIncEngineStats(output) {
+			for t, v := range stats {
+				ch <- prometheus.MustNewConstMetric(metricControllerIncEngineRuns, prometheus.CounterValue, v, node, t)
+			}
+		}
+	}
+
+	if output, err := e.appctl.call(socket, "lflow-cache/show-stats"); err != nil {
+		e.countRequestError("", "appctl_controller_lflow_cache_stats", err)
+		errs = append(errs, err)
+	} else {
+		for key, v := range parseLflowCacheStats(output) {
+			switch {
+			case key == "Enabled":
+				ch <- prometheus.MustNewConstMetric(metricControllerLflowCacheEnabled, prometheus.GaugeValue, v)
+			case key == "high-watermark":
+				ch <- prometheus.MustNewConstMetric(metricControllerLflowCacheHighWatermark, prometheus.GaugeValue, v)
+			case key == "trim count":
+				ch <- prometheus.MustNewConstMetric(metricControllerLflowCacheTrims, prometheus.CounterValue, v)
+			case key == "Mem usage (KB)":
+				ch <- prometheus.MustNewConstMetric(metricControllerLflowCacheMemory, prometheus.GaugeValue, v*1024)
+			case strings.HasPrefix(key, "cache-"):
+				ch <- prometheus.MustNewConstMetric(metricControllerLflowCacheEntries, prometheus.GaugeValue, v, strings.TrimPrefix(key, "cache-"))
+			}
+		}
+	}
+
+	if output, err := e.appctl.call(socket, "coverage/show"); err != nil {
+		e.countRequestError("", "appctl_controller_coverage", err)
+		errs = append(errs, err)
+	} else {
+		for event, v := range parseCoverage(output) {
+			ch <- prometheus.MustNewConstMetric(metricControllerCoverage, prometheus.CounterValue, v, event)
+		}
+	}
+
+	if output, err := e.appctl.call(socket, "memory/show"); err != nil {
+		e.countRequestError("", "appctl_controller_memory", err)
+		errs = append(errs, err)
+	} else {
+		for item, v := range parseMemory(output) {
+			ch <- prometheus.MustNewConstMetric(metricControllerMemory, prometheus.GaugeValue, v, item)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ovsStateValue maps an up or down state of an interface to 1 or 0.
+func ovsStateValue(state string) float64 {
+	if state == "up" {
+		return 1
+	}
+	return 0
+}
+
+func (e *Exporter) setOvsInfoMetric(ch chan<- prometheus.Metric) error {
+	bridges, err := e.getOvsBridges()
+	if err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(metricOvsBridgesNum, prometheus.GaugeValue, float64(len(bridges)))
+	for _, bridge := range bridges {
+		ch <- prometheus.MustNewConstMetric(metricOvsBridgePortsNum, prometheus.GaugeValue, float64(bridge.PortsNum),
+			bridge.UUID, bridge.Name, bridge.DatapathType)
+		ofportFailed := 0
+		for _, iface := range bridge.Interfaces {
+			if iface.AdminState != "" {
+				ch <- prometheus.MustNewConstMetric(metricOvsInterfaceAdminState, prometheus.GaugeValue, ovsStateValue(iface.AdminState),
+					iface.UUID, iface.Name, bridge.Name, iface.Type)
+			}
+			if iface.LinkState != "" {
+				ch <- prometheus.MustNewConstMetric(metricOvsInterfaceLinkState, prometheus.GaugeValue, ovsStateValue(iface.LinkState),
+					iface.UUID, iface.Name, bridge.Name, iface.Type)
+			}
+			if iface.Error != "" {
+				ch <- prometheus.MustNewConstMetric(metricOvsInterfaceError, prometheus.GaugeValue, 1,
+					iface.UUID, iface.Name, bridge.Name, iface.Error)
+			}
+			if iface.Ofport == -1 {
+				ofportFailed++
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(metricOvsInterfaceOfportFailedNum, prometheus.GaugeValue, float64(ofportFailed), bridge.UUID, bridge.Name)
+	}
+
+	socket, err := e.getVswitchdControlSocket()
+	if err != nil {
+		return fmt.Errorf("failed to get ovs-vswitchd control socket: %w", err)
+	}
+
+	var errs []error
+	if output, err := e.appctl.call(socket, "dpif/show"); err != nil {
+		e.countRequestError("", "appctl_vswitchd_dpif_show", err)
+		errs = append(errs, err)
+	} else {
+		for datapath, lookups := range parseDpifShow(output) {
+			for result, v := range lookups {
+				ch <- prometheus.MustNewConstMetric(metricOvsDatapathLookups, prometheus.CounterValue, v, datapath, result)
+			}
+		}
+	}
+
+	if output, err := e.appctl.call(socket, "upcall/show"); err != nil {
+		e.countRequestError("", "appctl_vswitchd_upcall_show", err)
+		errs = append(errs, err)
+	} else {
+		for datapath, stats := range parseUpcallShow(output) {
+			for stat, v := range stats.flows {
+				if stat == "limit" {
+					ch <- prometheus.MustNewConstMetric(metricOvsDatapathFlowLimit, prometheus.GaugeValue, v, datapath)
+				} else {
+					ch <- prometheus.MustNewConstMetric(metricOvsDatapathFlows, prometheus.GaugeValue, v, datapath, stat)
+				}
+			}
+			if stats.dumpDuration >= 0 {
+				ch <- prometheus.MustNewConstMetric(metricOvsUpcallDumpDuration, prometheus.GaugeValue, stats.dumpDuration, datapath)
+			}
+			for handler, v := range stats.handlerKeys {
+				ch <- prometheus.MustNewConstMetric(metricOvsUpcallHandlerKeys, prometheus.GaugeValue, v, datapath, handler)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (e *Exporter) getOvnStatusContent() map[string]string {
 	result := map[string]string{"ovsdb-server-northbound": "", "ovsdb-server-southbound": ""}
 
 	// get ovn-northbound status
-	cmdstr := "ovn-appctl -t /var/run/ovn/ovnnb_db.ctl cluster/status OVN_Northbound"
-	cmd := exec.Command("sh", "-c", cmdstr)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
+	if status, err := e.getClusterServers(e.nbSocketControl, e.nbFileDataPath, "OVN_Northbound"); err != nil {
 		slog.Error("get ovn-northbound status failed", "error", err)
-	}
-	if strings.Contains(string(output), "Servers:") {
-		servers := strings.Split(string(output), "Servers:")[1]
-		result["ovsdb-server-northbound"] = servers
+	} else {
+		result["ovsdb-server-northbound"] = status
 	}
 
 	// get ovn-southbound status
-	cmdstr = "ovn-appctl -t /var/run/ovn/ovnsb_db.ctl cluster/status OVN_Southbound"
-	cmd = exec.Command("sh", "-c", cmdstr)
-	output, err = cmd.CombinedOutput()
-	if err != nil {
+	if status, err := e.getClusterServers(e.sbSocketControl, e.sbFileDataPath, "OVN_Southbound"); err != nil {
 		slog.Error("get ovn-southbound status failed", "error", err)
-	}
-	if strings.Contains(string(output), "Servers:") {
-		servers := strings.Split(string(output), "Servers:")[1]
-		result["ovsdb-server-southbound"] = servers
+	} else {
+		result["ovsdb-server-southbound"] = status
 	}
 
 	return result
 }
 
+// getClusterServers returns the servers section of cluster/status, which is
+// empty for a standalone database.
+func (e *Exporter) getClusterServers(socket, file, dbName string) (string, error) {
+	clustered, err := getClusterEnableState(file)
+	if err != nil || !clustered {
+		return "", err
+	}
+	status, err := e.getClusterInfo(socket, dbName)
+	if err != nil {
+		return "", err
+	}
+	return status.servers, nil
+}
+
+// clusteredDBMagic starts every database file in raft format, standalone
+// databases start with "OVSDB JSON" instead.
+const clusteredDBMagic = "CLUSTER"
+
+// getClusterEnableState reports whether the database file is clustered, the
+// same check `ovsdb-tool db-is-clustered` does.
 func getClusterEnableState(dbName string) (bool, error) {
-	cmdstr := fmt.Sprintf("ovsdb-tool db-is-clustered %s", dbName)
-	cmd := exec.Command("sh", "-c", cmdstr)
-	_, err := cmd.CombinedOutput()
+	f, err := os.Open(dbName)
 	if err != nil {
-		slog.Error(fmt.Sprintf("%v", err))
 		return false, err
 	}
-	return true, nil
+	defer f.Close()
+
+	magic := make([]byte, len(clusteredDBMagic))
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return false, fmt.Errorf("failed to read database file %s: %w", dbName, err)
+	}
+	return string(magic) == clusteredDBMagic, nil
 }
 
-func (e *Exporter) setLogicalSwitchInfoMetric() {
-	lsws, err := e.Client.GetLogicalSwitches()
+// chassisOtherConfigKeys are the other_config keys of a chassis that are
+// exported, the others are too verbose or change too often.
+var chassisOtherConfigKeys = []string{
+	"ovn-cms-options",
+	"is-interconn",
+	"is-remote",
+	"datapath-type",
+	"ovn-bridge-mappings",
+}
+
+func (e *Exporter) setChassisHealthMetric(ch chan<- prometheus.Metric, rows *ovnChassisRows) error {
+	chassis, nbCfg, err := e.getChassisHealth(rows)
 	if err != nil {
-		slog.Error(fmt.Sprintf("%s", e.Client.Database.Southbound.Name), "error", err)
-		e.IncrementErrorCounter()
+		return err
+	}
+	now := time.Now()
+	for _, c := range chassis {
+		encapsNum := make(map[string]int)
+		for _, encap := range c.Encaps {
+			ch <- prometheus.MustNewConstMetric(metricChassisEncapInfo, prometheus.GaugeValue, 1, c.UUID, c.Name, encap.Type, encap.IP)
+			encapsNum[encap.Type]++
+		}
+		for t, n := range encapsNum {
+			ch <- prometheus.MustNewConstMetric(metricChassisEncapsNum, prometheus.GaugeValue, float64(n), c.UUID, c.Name, t)
+		}
+		if c.HasPrivate {
+			ch <- prometheus.MustNewConstMetric(metricChassisNbCfg, prometheus.GaugeValue, c.NbCfg, c.UUID, c.Name)
+			ch <- prometheus.MustNewConstMetric(metricChassisNbCfgLag, prometheus.GaugeValue, nbCfg-c.NbCfg, c.UUID, c.Name)
+			if c.NbCfgTimestamp > 0 {
+				age := now.Sub(time.UnixMilli(int64(c.NbCfgTimestamp))).Seconds()
+				ch <- prometheus.MustNewConstMetric(metricChassisNbCfgTimestampAge, prometheus.GaugeValue, age, c.UUID, c.Name)
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(metricChassisPortBindingsNum, prometheus.GaugeValue, float64(c.PortBindings), c.UUID, c.Name)
+		for _, key := range chassisOtherConfigKeys {
+			if value, ok := c.OtherConfig[key]; ok {
+				ch <- prometheus.MustNewConstMetric(metricChassisOtherConfig, prometheus.GaugeValue, 1, c.UUID, c.Name, key, value)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) setLogicalSwitchInfoMetric(ch chan<- prometheus.Metric) error {
+	lsws, err := e.getLogicalSwitches()
+	if err != nil {
+		return err
 	} else {
 		for _, lsw := range lsws {
-			metricLogicalSwitchInfo.WithLabelValues(lsw.UUID, lsw.Name).Set(1)
-			metricLogicalSwitchPortsNum.WithLabelValues(lsw.UUID, lsw.Name).Set(float64(len(lsw.Ports)))
+			ch <- prometheus.MustNewConstMetric(metricLogicalSwitchInfo, prometheus.GaugeValue, 1, lsw.UUID, lsw.Name)
+			ch <- prometheus.MustNewConstMetric(metricLogicalSwitchPortsNum, prometheus.GaugeValue, float64(len(lsw.Ports)), lsw.UUID, lsw.Name)
 			if len(lsw.Ports) > 0 {
 				for _, p := range lsw.Ports {
-					metricLogicalSwitchPortBinding.WithLabelValues(lsw.UUID, p, lsw.Name).Set(1)
+					ch <- prometheus.MustNewConstMetric(metricLogicalSwitchPortBinding, prometheus.GaugeValue, 1, lsw.UUID, p, lsw.Name)
 				}
 			}
 			if len(lsw.ExternalIDs) > 0 {
 				for k, v := range lsw.ExternalIDs {
-					metricLogicalSwitchExternalIDs.WithLabelValues(lsw.UUID, k, v, lsw.Name).Set(1)
+					ch <- prometheus.MustNewConstMetric(metricLogicalSwitchExternalIDs, prometheus.GaugeValue, 1, lsw.UUID, k, v, lsw.Name)
 				}
 			}
-			metricLogicalSwitchTunnelKey.WithLabelValues(lsw.UUID, lsw.Name).Set(float64(lsw.TunnelKey))
+			ch <- prometheus.MustNewConstMetric(metricLogicalSwitchTunnelKey, prometheus.GaugeValue, float64(lsw.TunnelKey), lsw.UUID, lsw.Name)
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) setLogicalRouterInfoMetric(ch chan<- prometheus.Metric) error {
+	routers, err := e.getLogicalRouters()
+	if err != nil {
+		return err
+	}
+	for _, lr := range routers {
+		ch <- prometheus.MustNewConstMetric(metricLogicalRouterInfo, prometheus.GaugeValue, 1, lr.UUID, lr.Name)
+		ch <- prometheus.MustNewConstMetric(metricLogicalRouterPortsNum, prometheus.GaugeValue, float64(len(lr.Ports)), lr.UUID, lr.Name)
+		ch <- prometheus.MustNewConstMetric(metricLogicalRouterStaticRoutesNum, prometheus.GaugeValue, float64(len(lr.StaticRoutes)), lr.UUID, lr.Name)
+		ch <- prometheus.MustNewConstMetric(metricLogicalRouterPoliciesNum, prometheus.GaugeValue, float64(len(lr.Policies)), lr.UUID, lr.Name)
+		ch <- prometheus.MustNewConstMetric(metricLogicalRouterNatRulesNum, prometheus.GaugeValue, float64(len(lr.Nat)), lr.UUID, lr.Name)
+		ch <- prometheus.MustNewConstMetric(metricLogicalRouterLoadBalancersNum, prometheus.GaugeValue, float64(len(lr.LoadBalancers)), lr.UUID, lr.Name)
+	}
+	return nil
+}
+
+func (e *Exporter) setLogicalRouterPortInfoMetric(ch chan<- prometheus.Metric) error {
+	ports, err := e.getLogicalRouterPorts()
+	if err != nil {
+		return err
+	}
+	for _, port := range ports {
+		ch <- prometheus.MustNewConstMetric(metricLogicalRouterPortInfo, prometheus.GaugeValue, 1, port.UUID, port.Name,
+			port.LogicalRouterName, port.MAC, strings.Join(port.Networks, " "), port.Peer)
+		if !port.IsGateway() {
+			continue
+		}
+
+		activeIsCandidate := false
+		for _, c := range port.GatewayChassis {
+			active := 0.0
+			if c.ChassisName == port.ActiveChassis {
+				active = 1
+				activeIsCandidate = true
+			}
+			ch <- prometheus.MustNewConstMetric(metricLogicalRouterPortGatewayChassisPriority, prometheus.GaugeValue, c.Priority,
+				port.UUID, port.Name, port.LogicalRouterName, c.ChassisName)
+			ch <- prometheus.MustNewConstMetric(metricLogicalRouterPortGatewayChassisActive, prometheus.GaugeValue, active,
+				port.UUID, port.Name, port.LogicalRouterName, c.ChassisName)
+		}
+		// the port may still be bound to a chassis that is no candidate anymore
+		if port.ActiveChassis != "" && !activeIsCandidate {
+			ch <- prometheus.MustNewConstMetric(metricLogicalRouterPortGatewayChassisActive, prometheus.GaugeValue, 1,
+				port.UUID, port.Name, port.LogicalRouterName, port.ActiveChassis)
+		}
+		if port.ActiveChassis != "" {
+			ch <- prometheus.MustNewConstMetric(metricLogicalRouterPortGatewayActive, prometheus.GaugeValue, 1, port.UUID, port.Name, port.LogicalRouterName)
+		} else {
+			ch <- prometheus.MustNewConstMetric(metricLogicalRouterPortGatewayActive, prometheus.GaugeValue, 0, port.UUID, port.Name, port.LogicalRouterName)
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) setNatInfoMetric(ch chan<- prometheus.Metric) error {
+	rules, err := e.getNatRules()
+	if err != nil {
+		return err
+	}
+
+	type router struct{ uuid, name string }
+	typeCounts := make(map[router]map[string]int)
+	// several snat rules commonly share an external IP, only dnat rules
+	// conflict when they do
+	externalIPCounts := make(map[string]int)
+	for _, nat := range rules {
+		r := router{nat.LogicalRouterUUID, nat.LogicalRouterName}
+		if typeCounts[r] == nil {
+			typeCounts[r] = make(map[string]int)
+		}
+		typeCounts[r][nat.Type]++
+		if nat.Type != "snat" {
+			externalIPCounts[nat.ExternalIP]++
+		}
+	}
+
+	for r, counts := range typeCounts {
+		for _, t := range ovnNatTypes {
+			ch <- prometheus.MustNewConstMetric(metricLogicalRouterNatTypeNum, prometheus.GaugeValue, float64(counts[t]), r.uuid, r.name, t)
+		}
+	}
+	for _, nat := range rules {
+		if nat.Type != "snat" && externalIPCounts[nat.ExternalIP] > 1 {
+			ch <- prometheus.MustNewConstMetric(metricNatDuplicateExternalIP, prometheus.GaugeValue, 1,
+				nat.UUID, nat.LogicalRouterName, nat.Type, nat.ExternalIP, nat.LogicalIP)
+		}
+		if e.natFipInfo && nat.Type == "dnat_and_snat" {
+			ch <- prometheus.MustNewConstMetric(metricNatFipInfo, prometheus.GaugeValue, 1,
+				nat.UUID, nat.LogicalRouterName, nat.LogicalIP, nat.ExternalIP, nat.ExternalMAC, nat.LogicalPort)
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) setLoadBalancerInfoMetric(ch chan<- prometheus.Metric) error {
+	lbs, err := e.getLoadBalancers()
+	if err != nil {
+		return err
+	}
+	for _, lb := range lbs {
+		ch <- prometheus.MustNewConstMetric(metricLoadBalancerInfo, prometheus.GaugeValue, 1, lb.UUID, lb.Name, lb.Protocol)
+		ch <- prometheus.MustNewConstMetric(metricLoadBalancerVipsNum, prometheus.GaugeValue, float64(len(lb.VIPs)), lb.UUID, lb.Name)
+		for vip, backends := range lb.VIPs {
+			ch <- prometheus.MustNewConstMetric(metricLoadBalancerVipBackendsNum, prometheus.GaugeValue, float64(len(backends)), lb.UUID, lb.Name, vip)
+		}
+		for _, ref := range lb.References {
+			ch <- prometheus.MustNewConstMetric(metricLoadBalancerReference, prometheus.GaugeValue, 1, lb.UUID, lb.Name, ref.Type, ref.UUID, ref.Name)
+		}
+	}
+
+	monitors, err := e.getServiceMonitors()
+	if err != nil {
+		return err
+	}
+	for _, m := range monitors {
+		status := m.Status
+		if status == "" {
+			status = "unknown"
+		}
+		online := 0.0
+		if status == "online" {
+			online = 1
+		}
+		ch <- prometheus.MustNewConstMetric(metricServiceMonitorOnline, prometheus.GaugeValue, online, m.IP, m.Port, m.Protocol, m.LogicalPort, status)
+	}
+	return nil
+}
+
+// aclPriorityBandWidth is the width of the priority bands ACLs are counted
+// in, ACL priorities range from 0 to 32767.
+const aclPriorityBandWidth = 1000
+
+// aclPriorityBand returns the priority band of an ACL priority, e.g.
+// 1000-1999.
+func aclPriorityBand(priority int) string {
+	low := priority / aclPriorityBandWidth * aclPriorityBandWidth
+	return fmt.Sprintf("%d-%d", low, low+aclPriorityBandWidth-1)
+}
+
+func (e *Exporter) setACLInfoMetric(ch chan<- prometheus.Metric) error {
+	acls, err := e.getACLs()
+	if err != nil {
+		return err
+	}
+	type aclKey struct{ ownerType, ownerName, direction, action, band string }
+	counts := make(map[aclKey]int)
+	for _, acl := range acls {
+		counts[aclKey{acl.OwnerType, acl.OwnerName, acl.Direction, acl.Action, aclPriorityBand(acl.Priority)}]++
+	}
+	for k, v := range counts {
+		ch <- prometheus.MustNewConstMetric(metricACLNum, prometheus.GaugeValue, float64(v), k.ownerType, k.ownerName, k.direction, k.action, k.band)
+	}
+	return nil
+}
+
+func (e *Exporter) setPortGroupInfoMetric(ch chan<- prometheus.Metric) error {
+	groups, err := e.getPortGroups()
+	if err != nil {
+		return err
+	}
+	for _, pg := range groups {
+		ch <- prometheus.MustNewConstMetric(metricPortGroupPortsNum, prometheus.GaugeValue, float64(len(pg.Ports)), pg.UUID, pg.Name)
+		ch <- prometheus.MustNewConstMetric(metricPortGroupACLsNum, prometheus.GaugeValue, float64(len(pg.ACLs)), pg.UUID, pg.Name)
+	}
+	return nil
+}
+
+func (e *Exporter) setAddressSetInfoMetric(ch chan<- prometheus.Metric) error {
+	sets, err := e.getAddressSets()
+	if err != nil {
+		return err
+	}
+	for _, as := range sets {
+		ch <- prometheus.MustNewConstMetric(metricAddressSetAddressesNum, prometheus.GaugeValue, float64(len(as.Addresses)), as.UUID, as.Name)
+	}
+	return nil
+}
+
+func (e *Exporter) setLogicalFlowInfoMetric(ch chan<- prometheus.Metric) error {
+	counts, groups, err := e.getLogicalFlowCounts()
+	if err != nil {
+		return err
+	}
+	for k, v := range counts {
+		ch <- prometheus.MustNewConstMetric(metricLogicalFlowNum, prometheus.GaugeValue, float64(v), k.Datapath, k.DatapathType, k.Pipeline, k.TableID, k.Stage)
+	}
+	for _, group := range groups {
+		ch <- prometheus.MustNewConstMetric(metricLogicalDPGroupDatapathsNum, prometheus.GaugeValue, float64(group.Datapaths), group.UUID)
+		ch <- prometheus.MustNewConstMetric(metricLogicalDPGroupFlowsNum, prometheus.GaugeValue, float64(group.Flows), group.UUID)
+	}
+	return nil
+}
+
+// portBindingTypes are the port binding types that are always counted, even
+// when there are none.
+var portBindingTypes = []string{"vif", "patch", "localnet", "l3gateway", "chassisredirect", "virtual"}
+
+func (e *Exporter) setPortBindingInfoMetric(ch chan<- prometheus.Metric) error {
+	bindings, hasUp, err := e.getPortBindings()
+	if err != nil {
+		return err
+	}
+	typeCounts := make(map[string]int, len(portBindingTypes))
+	for _, t := range portBindingTypes {
+		typeCounts[t] = 0
+	}
+	for _, pb := range bindings {
+		typeCounts[pb.Type]++
+		if hasUp {
+			up := 0.0
+			if pb.Up {
+				up = 1
+			}
+			ch <- prometheus.MustNewConstMetric(metricPortBindingUp, prometheus.GaugeValue, up, pb.UUID, pb.LogicalPort, pb.Type, pb.Datapath, pb.Chassis)
+		}
+		if pb.RequestedChassis != "" {
+			mismatch := 0.0
+			if pb.RequestedChassis != pb.Chassis {
+				mismatch = 1
+			}
+			ch <- prometheus.MustNewConstMetric(metricPortBindingRequestedChassisMismatch, prometheus.GaugeValue, mismatch,
+				pb.UUID, pb.LogicalPort, pb.RequestedChassis, pb.Chassis)
+		}
+	}
+	for t, n := range typeCounts {
+		ch <- prometheus.MustNewConstMetric(metricPortBindingsNum, prometheus.GaugeValue, float64(n), t)
+	}
+	return nil
+}
+
+// macBindingAgeBuckets are the buckets of ovn_mac_binding_age_seconds, from
+// a minute to a month.
+var macBindingAgeBuckets = []float64{60, 300, 900, 3600, 6 * 3600, 24 * 3600, 7 * 24 * 3600, 30 * 24 * 3600}
+
+func (e *Exporter) setMacBindingInfoMetric(ch chan<- prometheus.Metric) error {
+	counts, ages, err := e.getMacBindingCounts()
+	if err != nil {
+		return err
+	}
+	for k, v := range counts {
+		ch <- prometheus.MustNewConstMetric(metricMacBindingsNum, prometheus.GaugeValue, float64(v), k.Datapath, k.LogicalPort)
+	}
+	if ages == nil {
+		return nil
+	}
+
+	var sum float64
+	buckets := make(map[float64]uint64, len(macBindingAgeBuckets))
+	for _, age := range ages {
+		sum += age
+		for _, bound := range macBindingAgeBuckets {
+			if age <= bound {
+				buckets[bound]++
+			}
 		}
 	}
+	ch <- prometheus.MustNewConstHistogram(metricMacBindingAge, uint64(len(ages)), sum, buckets)
+	return nil
+}
+
+func (e *Exporter) setFdbInfoMetric(ch chan<- prometheus.Metric) error {
+	counts, err := e.getFdbCounts()
+	if err != nil {
+		return err
+	}
+	for k, v := range counts {
+		ch <- prometheus.MustNewConstMetric(metricFdbEntriesNum, prometheus.GaugeValue, float64(v), k.Datapath, k.LogicalPort)
+	}
+	return nil
 }
 
-func lspAddress(addresses []ovsdb.OvnLogicalSwitchPortAddress) (mac, ip string) {
+func (e *Exporter) setGlobalInfoMetric(ch chan<- prometheus.Metric) error {
+	g, err := e.getGlobal()
+	if err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(metricSbGlobalNbCfg, prometheus.GaugeValue, g.SbNbCfg)
+
+	now := time.Now()
+	nbCfgTime := time.UnixMilli(int64(g.NbCfgTimestamp))
+	for _, stage := range []struct {
+		name      string
+		cfg       float64
+		timestamp float64
+	}{
+		{"nb", g.NbCfg, g.NbCfgTimestamp},
+		{"sb", g.SbCfg, g.SbCfgTimestamp},
+		{"hv", g.HvCfg, g.HvCfgTimestamp},
+	} {
+		ch <- prometheus.MustNewConstMetric(metricNbGlobalCfg, prometheus.GaugeValue, stage.cfg, stage.name+"_cfg")
+		// a stage that never completed has no timestamp
+		if stage.timestamp != 0 {
+			ch <- prometheus.MustNewConstMetric(metricNbGlobalCfgTimestamp, prometheus.GaugeValue, stage.timestamp/1000, stage.name+"_cfg")
+		}
+		if stage.name == "nb" {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(metricNbCfgBehind, prometheus.GaugeValue, g.NbCfg-stage.cfg, stage.name)
+		// nothing was ever requested with --wait
+		if g.NbCfgTimestamp == 0 {
+			continue
+		}
+		propagation := now.Sub(nbCfgTime)
+		if stage.cfg >= g.NbCfg {
+			propagation = time.UnixMilli(int64(stage.timestamp)).Sub(nbCfgTime)
+		}
+		ch <- prometheus.MustNewConstMetric(metricNbCfgPropagation, prometheus.GaugeValue, propagation.Seconds(), stage.name)
+	}
+	return nil
+}
+
+func lspAddress(addresses []OvnLogicalSwitchPortAddress) (mac, ip string) {
 	if len(addresses) == 0 {
 		return "", ""
 	}
@@ -168,33 +871,116 @@ func lspAddress(addresses []ovsdb.OvnLogicalSwitchPortAddress) (mac, ip string)
 	return
 }
 
-func (e *Exporter) setLogicalSwitchPortInfoMetric() {
-	lswps, err := e.Client.GetLogicalSwitchPorts()
+func (e *Exporter) setLogicalSwitchPortInfoMetric(ch chan<- prometheus.Metric) error {
+	lswps, err := e.getLogicalSwitchPorts()
 	if err != nil {
-		slog.Error(fmt.Sprintf("%s", e.Client.Database.Southbound.Name), "error", err)
-		e.IncrementErrorCounter()
+		return err
 	} else {
 		for _, port := range lswps {
 			mac, ip := lspAddress(port.Addresses)
-			metricLogicalSwitchPortInfo.WithLabelValues(port.UUID, port.Name, port.ChassisUUID,
-				port.LogicalSwitchName, port.DatapathUUID, port.PortBindingUUID, mac, ip).Set(1)
-			metricLogicalSwitchPortTunnelKey.WithLabelValues(port.UUID, port.LogicalSwitchName, port.Name).Set(float64(port.TunnelKey))
+			ch <- prometheus.MustNewConstMetric(metricLogicalSwitchPortInfo, prometheus.GaugeValue, 1, port.UUID, port.Name, port.ChassisUUID,
+				port.LogicalSwitchName, port.DatapathUUID, port.PortBindingUUID, mac, ip)
+			ch <- prometheus.MustNewConstMetric(metricLogicalSwitchPortTunnelKey, prometheus.GaugeValue, float64(port.TunnelKey), port.UUID, port.LogicalSwitchName, port.Name)
 		}
 	}
+	return nil
 }
 
-func getClusterInfo(socket, dbName string) (*OVNDBClusterStatus, error) {
-	clusterStatus := &OVNDBClusterStatus{}
-	var err error
+// getClusterInfo returns the cluster status of the database. cluster/status
+// is requested at most once per collection cycle, the status and the
+// ovn_status collectors share its result.
+func (e *Exporter) getClusterInfo(socket, dbName string) (*OVNDBClusterStatus, error) {
+	if result, ok := e.clusterStatuses[dbName]; ok {
+		return result.status, result.err
+	}
 
-	cmdstr := fmt.Sprintf("ovn-appctl -t %s cluster/status %s", socket, dbName)
-	cmd := exec.Command("sh", "-c", cmdstr)
-	output, err := cmd.CombinedOutput()
+	var result clusterStatusResult
+	output, err := e.appctl.call(socket, "cluster/status", dbName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve cluster/status info for database %s: %v", dbName, err)
+		e.countRequestError(dbName, "appctl_cluster_status", err)
+		result.err = fmt.Errorf("failed to retrieve cluster/status info for database %s: %w", dbName, err)
+	} else {
+		result.status = parseClusterStatus(output)
+	}
+	e.clusterStatuses[dbName] = result
+	return result.status, result.err
+}
+
+// sharedSbTables are the southbound tables several collectors read, along
+// with the request error operation and the columns any of them needs.
+var sharedSbTables = map[string]struct {
+	operation string
+	columns   []string
+}{
+	"Chassis": {
+		operation: "get_chassis",
+		columns:   []string{"hostname", "name", "encaps", "external_ids", "other_config"},
+	},
+	"Datapath_Binding": {
+		operation: "get_datapath_bindings",
+		columns:   []string{"external_ids", "tunnel_key"},
+	},
+	"Port_Binding": {
+		operation: "get_port_bindings",
+		columns:   []string{"logical_port", "type", "datapath", "chassis", "tunnel_key", "up", "requested_chassis"},
+	},
+}
+
+// selectSbRows returns the rows of a table of sharedSbTables. The table is
+// selected once per collection cycle, with the columns the schema of the
+// southbound database has.
+func (e *Exporter) selectSbRows(table string) ([]ovsdbRow, error) {
+	if result, ok := e.sbRows[table]; ok {
+		return result.rows, result.err
+	}
+
+	shared := sharedSbTables[table]
+	var result sbRowsResult
+	columns := []string{"_uuid"}
+	for _, column := range shared.columns {
+		ok, err := e.sbClient.HasColumn(table, column)
+		if err != nil {
+			result.err = err
+			break
+		}
+		if ok {
+			columns = append(columns, column)
+		}
+	}
+	if result.err == nil {
+		result.rows, result.err = e.sbClient.Select(table, columns...)
+	}
+	if result.err != nil {
+		e.countRequestError(e.sbClient.database, shared.operation, result.err)
+	}
+	e.sbRows[table] = result
+	return result.rows, result.err
+}
+
+// parseClusterStatus parses the output of cluster/status.
+func parseClusterStatus(output string) *OVNDBClusterStatus {
+	clusterStatus := &OVNDBClusterStatus{}
+	if _, servers, ok := strings.Cut(output, "Servers:"); ok {
+		clusterStatus.servers = servers
 	}
 
-	for _, line := range strings.Split(string(output), "\n") {
+	inbound := make(map[string]bool)
+	outbound := make(map[string]bool)
+	inServers := false
+	for _, line := range strings.Split(output, "\n") {
+		if inServers && strings.HasPrefix(line, " ") {
+			peer, self, ok := parseClusterServer(line)
+			if !ok {
+				continue
+			}
+			if self {
+				clusterStatus.nextIndex = peer.nextIndex
+				clusterStatus.matchIndex = peer.matchIndex
+			} else {
+				clusterStatus.peers = append(clusterStatus.peers, peer)
+			}
+			continue
+		}
 		idx := strings.Index(line, ":")
 		if idx == -1 {
 			continue
@@ -247,8 +1033,10 @@ func getClusterInfo(socket, dbName string) (*OVNDBClusterStatus, error) {
 					switch {
 					case strings.HasPrefix(conn, "->"):
 						connOut++
+						outbound[strings.TrimPrefix(conn, "->")] = true
 					case strings.HasPrefix(conn, "<-"):
 						connIn++
+						inbound[strings.TrimPrefix(conn, "<-")] = true
 					case strings.HasPrefix(conn, "(->"):
 						connOutErr++
 					case strings.HasPrefix(conn, "(<-"):
@@ -260,51 +1048,113 @@ func getClusterInfo(socket, dbName string) (*OVNDBClusterStatus, error) {
 				clusterStatus.connInErr = connInErr
 				clusterStatus.connOutErr = connOutErr
 			}
+		case "Servers":
+			inServers = true
 		}
 	}
 
-	return clusterStatus, nil
+	for _, peer := range clusterStatus.peers {
+		peer.inbound = inbound[peer.id]
+		peer.outbound = outbound[peer.id]
+	}
+
+	return clusterStatus
 }
 
-func (e *Exporter) setOvnClusterInfoMetric(c *OVNDBClusterStatus, dbName string) {
-	metricClusterRole.WithLabelValues(dbName, c.sid, c.cid, c.role).Set(1)
-	metricClusterStatus.WithLabelValues(dbName, c.sid, c.cid, c.status).Set(1)
-	metricClusterTerm.WithLabelValues(dbName, c.sid, c.cid).Set(c.term)
+var (
+	clusterServerRegex     = regexp.MustCompile(`^(\S+) \(\S+ at ([^)]+)\)`)
+	clusterNextIndexRegex  = regexp.MustCompile(`next_index=(\d+)`)
+	clusterMatchIndexRegex = regexp.MustCompile(`match_index=(\d+)`)
+	clusterLastMsgRegex    = regexp.MustCompile(`last msg (\d+) ms ago`)
+)
+
+// parseClusterServer parses an entry of the `Servers:` block of cluster/status,
+// which is of the format
+// `4e34 (4e34 at tcp:192.168.0.3:6643) next_index=1109 match_index=1108 last msg 120 ms ago`.
+// The next and match index are only known by the leader.
+func parseClusterServer(line string) (peer *OVNDBClusterPeer, self bool, ok bool) {
+	line = strings.TrimSpace(line)
+	m := clusterServerRegex.FindStringSubmatch(line)
+	if m == nil {
+		return nil, false, false
+	}
+	peer = &OVNDBClusterPeer{id: m[1], address: m[2], lastMsgAge: -1}
+	if m := clusterNextIndexRegex.FindStringSubmatch(line); m != nil {
+		if value, err := strconv.ParseFloat(m[1], 64); err == nil {
+			peer.nextIndex = value
+		}
+	}
+	if m := clusterMatchIndexRegex.FindStringSubmatch(line); m != nil {
+		if value, err := strconv.ParseFloat(m[1], 64); err == nil {
+			peer.matchIndex = value
+		}
+	}
+	if m := clusterLastMsgRegex.FindStringSubmatch(line); m != nil {
+		if value, err := strconv.ParseFloat(m[1], 64); err == nil {
+			peer.lastMsgAge = value / 1000
+		}
+	}
+	return peer, strings.Contains(line, "(self)"), true
+}
+
+func (e *Exporter) setOvnClusterInfoMetric(ch chan<- prometheus.Metric, c *OVNDBClusterStatus, dbName string) {
+	ch <- prometheus.MustNewConstMetric(metricClusterRole, prometheus.GaugeValue, 1, dbName, c.sid, c.cid, c.role)
+	ch <- prometheus.MustNewConstMetric(metricClusterStatus, prometheus.GaugeValue, 1, dbName, c.sid, c.cid, c.status)
+	ch <- prometheus.MustNewConstMetric(metricClusterTerm, prometheus.GaugeValue, c.term, dbName, c.sid, c.cid)
 
 	if c.leader == "self" {
-		metricClusterLeaderSelf.WithLabelValues(dbName, c.sid, c.cid).Set(1)
+		ch <- prometheus.MustNewConstMetric(metricClusterLeaderSelf, prometheus.GaugeValue, 1, dbName, c.sid, c.cid)
 	} else {
-		metricClusterLeaderSelf.WithLabelValues(dbName, c.sid, c.cid).Set(0)
+		ch <- prometheus.MustNewConstMetric(metricClusterLeaderSelf, prometheus.GaugeValue, 0, dbName, c.sid, c.cid)
 	}
 	if c.vote == "self" {
-		metricClusterVoteSelf.WithLabelValues(dbName, c.sid, c.cid).Set(1)
+		ch <- prometheus.MustNewConstMetric(metricClusterVoteSelf, prometheus.GaugeValue, 1, dbName, c.sid, c.cid)
 	} else {
-		metricClusterVoteSelf.WithLabelValues(dbName, c.sid, c.cid).Set(0)
+		ch <- prometheus.MustNewConstMetric(metricClusterVoteSelf, prometheus.GaugeValue, 0, dbName, c.sid, c.cid)
 	}
 
-	metricClusterElectionTimer.WithLabelValues(dbName, c.sid, c.cid).Set(c.electionTimer)
-	metricClusterNotCommittedEntryCount.WithLabelValues(dbName, c.sid, c.cid).Set(c.logNotCommitted)
-	metricClusterNotAppliedEntryCount.WithLabelValues(dbName, c.sid, c.cid).Set(c.logNotApplied)
-	metricClusterLogIndexStart.WithLabelValues(dbName, c.sid, c.cid).Set(c.logIndexStart)
-	metricClusterLogIndexNext.WithLabelValues(dbName, c.sid, c.cid).Set(c.logIndexNext)
+	ch <- prometheus.MustNewConstMetric(metricClusterElectionTimer, prometheus.GaugeValue, c.electionTimer, dbName, c.sid, c.cid)
+	ch <- prometheus.MustNewConstMetric(metricClusterNotCommittedEntryCount, prometheus.GaugeValue, c.logNotCommitted, dbName, c.sid, c.cid)
+	ch <- prometheus.MustNewConstMetric(metricClusterNotAppliedEntryCount, prometheus.GaugeValue, c.logNotApplied, dbName, c.sid, c.cid)
+	ch <- prometheus.MustNewConstMetric(metricClusterLogIndexStart, prometheus.GaugeValue, c.logIndexStart, dbName, c.sid, c.cid)
+	ch <- prometheus.MustNewConstMetric(metricClusterLogIndexNext, prometheus.GaugeValue, c.logIndexNext, dbName, c.sid, c.cid)
 
-	metricClusterInConnTotal.WithLabelValues(dbName, c.sid, c.cid).Set(c.connIn)
-	metricClusterOutConnTotal.WithLabelValues(dbName, c.sid, c.cid).Set(c.connOut)
-	metricClusterInConnErrTotal.WithLabelValues(dbName, c.sid, c.cid).Set(c.connInErr)
-	metricClusterOutConnErrTotal.WithLabelValues(dbName, c.sid, c.cid).Set(c.connOutErr)
+	ch <- prometheus.MustNewConstMetric(metricClusterInConnTotal, prometheus.GaugeValue, c.connIn, dbName, c.sid, c.cid)
+	ch <- prometheus.MustNewConstMetric(metricClusterOutConnTotal, prometheus.GaugeValue, c.connOut, dbName, c.sid, c.cid)
+	ch <- prometheus.MustNewConstMetric(metricClusterInConnErrTotal, prometheus.GaugeValue, c.connInErr, dbName, c.sid, c.cid)
+	ch <- prometheus.MustNewConstMetric(metricClusterOutConnErrTotal, prometheus.GaugeValue, c.connOutErr, dbName, c.sid, c.cid)
+
+	ch <- prometheus.MustNewConstMetric(metricClusterNextIndex, prometheus.GaugeValue, c.nextIndex, dbName, c.sid, c.cid)
+	ch <- prometheus.MustNewConstMetric(metricClusterMatchIndex, prometheus.GaugeValue, c.matchIndex, dbName, c.sid, c.cid)
+	ch <- prometheus.MustNewConstMetric(metricClusterPeerCount, prometheus.GaugeValue, float64(len(c.peers)), dbName, c.sid, c.cid)
+	for _, p := range c.peers {
+		if p.inbound {
+			ch <- prometheus.MustNewConstMetric(metricClusterPeerInConnInfo, prometheus.GaugeValue, 1, dbName, c.sid, c.cid, p.id, p.address)
+		}
+		if p.outbound {
+			ch <- prometheus.MustNewConstMetric(metricClusterPeerOutConnInfo, prometheus.GaugeValue, 1, dbName, c.sid, c.cid, p.id, p.address)
+		}
+		// only the leader tracks the replication progress of its followers
+		if c.role == "leader" {
+			ch <- prometheus.MustNewConstMetric(metricClusterPeerNextIndex, prometheus.GaugeValue, p.nextIndex, dbName, c.sid, c.cid, p.id)
+			ch <- prometheus.MustNewConstMetric(metricClusterPeerMatchIndex, prometheus.GaugeValue, p.matchIndex, dbName, c.sid, c.cid, p.id)
+		}
+		if p.lastMsgAge >= 0 {
+			ch <- prometheus.MustNewConstMetric(metricClusterPeerLastMsgAge, prometheus.GaugeValue, p.lastMsgAge, dbName, c.sid, c.cid, p.id)
+		}
+	}
 }
 
-func getDBStatus(socket string, dbName string) (bool, error) {
+func (e *Exporter) getDBStatus(socket string, dbName string) (bool, error) {
 	var result bool
-	cmdstr := fmt.Sprintf("ovn-appctl -t %s ovsdb-server/get-db-storage-status %s", socket, dbName)
 
-	cmd := exec.Command("sh", "-c", cmdstr)
-	output, err := cmd.CombinedOutput()
+	output, err := e.appctl.call(socket, "ovsdb-server/get-db-storage-status", dbName)
 	if err != nil {
 		slog.Error("ovn command ovsdb-server/get-db-storage-status failed", "database", dbName, "error", err)
+		e.countRequestError(dbName, "appctl_db_storage_status", err)
 		return false, err
 	}
-	lines := strings.Split(string(output), "\n")
+	lines := strings.Split(output, "\n")
 	for _, line := range lines {
 		if strings.Contains(line, "status: ok") {
 			result = true
@@ -318,35 +1168,3 @@ func getDBStatus(socket string, dbName string) (bool, error) {
 
 	return result, nil
 }
-
-func resetLogicalSwitchMetrics() {
-	metricLogicalSwitchInfo.Reset()
-	metricLogicalSwitchPortsNum.Reset()
-	metricLogicalSwitchPortBinding.Reset()
-	metricLogicalSwitchExternalIDs.Reset()
-	metricLogicalSwitchTunnelKey.Reset()
-}
-
-func resetLogicalSwitchPortMetrics() {
-	metricLogicalSwitchPortInfo.Reset()
-	metricLogicalSwitchPortTunnelKey.Reset()
-}
-
-func resetOvnClusterMetrics() {
-	metricClusterRole.Reset()
-	metricClusterStatus.Reset()
-	metricClusterTerm.Reset()
-	metricClusterLeaderSelf.Reset()
-	metricClusterVoteSelf.Reset()
-
-	metricClusterElectionTimer.Reset()
-	metricClusterNotCommittedEntryCount.Reset()
-	metricClusterNotAppliedEntryCount.Reset()
-	metricClusterLogIndexStart.Reset()
-	metricClusterLogIndexNext.Reset()
-
-	metricClusterInConnTotal.Reset()
-	metricClusterOutConnTotal.Reset()
-	metricClusterInConnErrTotal.Reset()
-	metricClusterOutConnErrTotal.Reset()
-}
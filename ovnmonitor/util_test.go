@@ -0,0 +1,287 @@
+package ovnmonitor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+const clusterStatusLeader = `f1a5
+Name: OVN_Northbound
+Cluster ID: 45ef (45ef51b9-9401-46e7-810d-6db0fc344ea2)
+Server ID: f1a5 (f1a5c5b4-0ab0-4c61-8f47-1a7c0e0e9c5f)
+Address: tcp:192.168.0.1:6643
+Status: cluster member
+Role: leader
+Term: 5
+Leader: self
+Vote: self
+
+Last Election started 86453312 ms ago, reason: timeout
+Last Election won: 86453310 ms ago
+Election timer: 1000
+Log: [2, 1108]
+Entries not yet committed: 0
+Entries not yet applied: 0
+Connections: ->4e34 ->9b2c <-4e34 <-9b2c
+Disconnections: 0
+Servers:
+    f1a5 (f1a5 at tcp:192.168.0.1:6643) (self) next_index=2 match_index=1107
+    4e34 (4e34 at tcp:192.168.0.3:6643) next_index=1108 match_index=1107 last msg 120 ms ago
+    9b2c (9b2c at tcp:192.168.0.2:6643) next_index=1108 match_index=1107 last msg 2500 ms ago
+`
+
+const clusterStatusFollower = `4e34
+Name: OVN_Southbound
+Cluster ID: 8c1d (8c1d7e2f-3a4b-4c5d-9e6f-7a8b9c0d1e2f)
+Server ID: 4e34 (4e34a1b2-c3d4-4e5f-8a9b-0c1d2e3f4a5b)
+Address: ssl:192.168.0.3:6644
+Status: cluster member
+Role: follower
+Term: 7
+Leader: f1a5
+Vote: unknown
+
+Election timer: 5000
+Log: [1021, 1398]
+Entries not yet committed: 2
+Entries not yet applied: 1
+Connections: ->0000 (->9b2c) <-f1a5
+Disconnections: 3
+Servers:
+    f1a5 (f1a5 at ssl:192.168.0.1:6644)
+    4e34 (4e34 at ssl:192.168.0.3:6644) (self)
+    9b2c (9b2c at ssl:192.168.0.2:6644)
+`
+
+func TestParseClusterStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   *OVNDBClusterStatus
+	}{
+		{
+			name:   "leader",
+			output: clusterStatusLeader,
+			want: &OVNDBClusterStatus{
+				cid:           "45ef51b9-9401-46e7-810d-6db0fc344ea2",
+				sid:           "f1a5c5b4-0ab0-4c61-8f47-1a7c0e0e9c5f",
+				status:        "cluster member",
+				role:          "leader",
+				leader:        "self",
+				vote:          "self",
+				term:          5,
+				electionTimer: 1000,
+				logIndexStart: 2,
+				logIndexNext:  1108,
+				connIn:        2,
+				connOut:       2,
+				nextIndex:     2,
+				matchIndex:    1107,
+				peers: []*OVNDBClusterPeer{
+					{id: "4e34", address: "tcp:192.168.0.3:6643", nextIndex: 1108, matchIndex: 1107, lastMsgAge: 0.12, inbound: true, outbound: true},
+					{id: "9b2c", address: "tcp:192.168.0.2:6643", nextIndex: 1108, matchIndex: 1107, lastMsgAge: 2.5, inbound: true, outbound: true},
+				},
+				servers: `
+    f1a5 (f1a5 at tcp:192.168.0.1:6643) (self) next_index=2 match_index=1107
+    4e34 (4e34 at tcp:192.168.0.3:6643) next_index=1108 match_index=1107 last msg 120 ms ago
+    9b2c (9b2c at tcp:192.168.0.2:6643) next_index=1108 match_index=1107 last msg 2500 ms ago
+`,
+			},
+		},
+		{
+			name:   "follower",
+			output: clusterStatusFollower,
+			want: &OVNDBClusterStatus{
+				cid:             "8c1d7e2f-3a4b-4c5d-9e6f-7a8b9c0d1e2f",
+				sid:             "4e34a1b2-c3d4-4e5f-8a9b-0c1d2e3f4a5b",
+				status:          "cluster member",
+				role:            "follower",
+				leader:          "f1a5",
+				vote:            "unknown",
+				term:            7,
+				electionTimer:   5000,
+				logIndexStart:   1021,
+				logIndexNext:    1398,
+				logNotCommitted: 2,
+				logNotApplied:   1,
+				connIn:          1,
+				connOut:         1,
+				connOutErr:      1,
+				peers: []*OVNDBClusterPeer{
+					{id: "f1a5", address: "ssl:192.168.0.1:6644", lastMsgAge: -1, inbound: true},
+					{id: "9b2c", address: "ssl:192.168.0.2:6644", lastMsgAge: -1},
+				},
+				servers: `
+    f1a5 (f1a5 at ssl:192.168.0.1:6644)
+    4e34 (4e34 at ssl:192.168.0.3:6644) (self)
+    9b2c (9b2c at ssl:192.168.0.2:6644)
+`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		got := parseClusterStatus(tt.output)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: parseClusterStatus() = %+v, want %+v", tt.name, got, tt.want)
+			for i := range got.peers {
+				t.Logf("%s: peer %d = %+v", tt.name, i, got.peers[i])
+			}
+		}
+	}
+}
+
+func TestParseClusterServer(t *testing.T) {
+	tests := []struct {
+		line     string
+		wantPeer *OVNDBClusterPeer
+		wantSelf bool
+		wantOk   bool
+	}{
+		{
+			line:     "    4e34 (4e34 at tcp:192.168.0.3:6643) next_index=1109 match_index=1108 last msg 120 ms ago",
+			wantPeer: &OVNDBClusterPeer{id: "4e34", address: "tcp:192.168.0.3:6643", nextIndex: 1109, matchIndex: 1108, lastMsgAge: 0.12},
+			wantOk:   true,
+		},
+		{
+			line:     "    f1a5 (f1a5 at tcp:192.168.0.1:6643) (self) next_index=2 match_index=1108",
+			wantPeer: &OVNDBClusterPeer{id: "f1a5", address: "tcp:192.168.0.1:6643", nextIndex: 2, matchIndex: 1108, lastMsgAge: -1},
+			wantSelf: true,
+			wantOk:   true,
+		},
+		{
+			line:     "    9b2c (9b2c at ssl:[fd00::2]:6644)",
+			wantPeer: &OVNDBClusterPeer{id: "9b2c", address: "ssl:[fd00::2]:6644", lastMsgAge: -1},
+			wantOk:   true,
+		},
+		{
+			line: "Disconnections: 0",
+		},
+	}
+	for _, tt := range tests {
+		peer, self, ok := parseClusterServer(tt.line)
+		if !reflect.DeepEqual(peer, tt.wantPeer) || self != tt.wantSelf || ok != tt.wantOk {
+			t.Errorf("parseClusterServer(%q) = %+v, %v, %v, want %+v, %v, %v", tt.line, peer, self, ok, tt.wantPeer, tt.wantSelf, tt.wantOk)
+		}
+	}
+}
+
+func TestRequestErrorClass(t *testing.T) {
+	var syntaxErr error = json.Unmarshal([]byte("{"), &struct{}{})
+	var typeErr error = json.Unmarshal([]byte(`"a"`), new(int))
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"unixctl timeout", &UnixctlError{Class: ErrUnixctlTimeout, Err: os.ErrDeadlineExceeded}, "timeout"},
+		{"ovsdb deadline", &net.OpError{Op: "read", Net: "unix", Err: os.ErrDeadlineExceeded}, "timeout"},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, "connection_refused"},
+		{"missing socket", &UnixctlError{Class: ErrUnixctlConnect, Err: os.NewSyscallError("connect", syscall.ENOENT)}, "connection_error"},
+		{"connection reset", &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, "connection_error"},
+		{"closed by the server", fmt.Errorf("select failed: %w", io.EOF), "connection_error"},
+		{"unixctl command", &UnixctlError{Class: ErrUnixctlCommand, Err: errors.New(`"cluster/status" is not a valid command`)}, "command_error"},
+		{"ovsdb operation", fmt.Errorf("%w: no table named Load_Balancer_Group", errOvsdbOperation), "command_error"},
+		{"not leader", errNotLeader, "command_error"},
+		{"json-rpc error", &jsonrpcError{Message: "unknown database"}, "command_error"},
+		{"unixctl protocol", &UnixctlError{Class: ErrUnixctlProtocol, Err: errors.New("unexpected reply id")}, "parse_error"},
+		{"json syntax", syntaxErr, "parse_error"},
+		{"json type", typeErr, "parse_error"},
+		{"other", errors.New("no remote configured for OVN_Northbound"), "other"},
+	}
+	for _, tt := range tests {
+		if got := requestErrorClass(tt.err); got != tt.want {
+			t.Errorf("%s: requestErrorClass(%v) = %q, want %q", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestGetClusterInfoOncePerCycle(t *testing.T) {
+	socket := serveUnixctl(t, map[string]unixctlReply{
+		"cluster/status OVN_Northbound": {output: clusterStatusLeader},
+	})
+	e := &Exporter{
+		appctl:          newUnixctlClient(time.Second),
+		requestErrors:   make(map[requestErrorKey]float64),
+		clusterStatuses: make(map[string]clusterStatusResult),
+	}
+
+	// the status and ovn_status collectors share the result of a cycle
+	for i := 0; i < 2; i++ {
+		status, err := e.getClusterInfo(socket, "OVN_Northbound")
+		if err != nil || status.role != "leader" {
+			t.Fatalf("getClusterInfo() = %+v, %v, want the leader status", status, err)
+		}
+		if _, err := e.getClusterInfo(socket, "OVN_Southbound"); err == nil {
+			t.Fatalf("getClusterInfo() of a standalone database succeeded, want error")
+		}
+	}
+	key := requestErrorKey{database: "OVN_Southbound", operation: "appctl_cluster_status", class: "command_error"}
+	if got := e.requestErrors[key]; got != 1 {
+		t.Errorf("failed cluster/status requests = %v, want 1", got)
+	}
+}
+
+func TestGetDBRoleStandalone(t *testing.T) {
+	dir := t.TempDir()
+	standalone := filepath.Join(dir, "ovnsb_db.db")
+	clustered := filepath.Join(dir, "ovnnb_db.db")
+	if err := os.WriteFile(standalone, []byte("OVSDB JSON 1 2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(clustered, []byte("CLUSTER 1 2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	socket := serveUnixctl(t, map[string]unixctlReply{
+		"cluster/status OVN_Northbound":                     {output: clusterStatusLeader},
+		"ovsdb-server/get-db-storage-status OVN_Southbound": {output: "status: ok"},
+	})
+	e := &Exporter{
+		appctl:          newUnixctlClient(time.Second),
+		requestErrors:   make(map[requestErrorKey]float64),
+		clusterStatuses: make(map[string]clusterStatusResult),
+	}
+
+	if role, err := e.getDBRole(socket, clustered, "OVN_Northbound"); err != nil || role != 3 {
+		t.Errorf("getDBRole() of the clustered leader = %d, %v, want 3", role, err)
+	}
+	// a standalone database has no cluster/status to ask for
+	if role, err := e.getDBRole(socket, standalone, "OVN_Southbound"); err != nil || role != 3 {
+		t.Errorf("getDBRole() of a standalone database = %d, %v, want 3", role, err)
+	}
+	if len(e.requestErrors) != 0 {
+		t.Errorf("request errors = %v, want none", e.requestErrors)
+	}
+}
+
+func TestSelectSbRowsOncePerCycle(t *testing.T) {
+	// an older schema without the up and requested_chassis columns
+	sb := newTestOvsdbClient(t, "OVN_Southbound", `{
+		"Port_Binding": [
+			{"_uuid": ["uuid", "pb1"], "logical_port": "lsp1", "type": "", "datapath": ["uuid", "dp1"], "chassis": ["set", []], "tunnel_key": 1}
+		]
+	}`)
+	e := newTestExporter(nil, sb)
+
+	for i := 0; i < 2; i++ {
+		rows, err := e.selectSbRows("Port_Binding")
+		if err != nil || len(rows) != 1 || rows[0].String("logical_port") != "lsp1" {
+			t.Fatalf("selectSbRows() = %v, %v, want the row of lsp1", rows, err)
+		}
+		if _, err := e.selectSbRows("Datapath_Binding"); err == nil {
+			t.Fatalf("selectSbRows() of a missing table succeeded, want error")
+		}
+	}
+	key := requestErrorKey{database: "OVN_Southbound", operation: "get_datapath_bindings", class: "command_error"}
+	if got := e.requestErrors[key]; got != 1 {
+		t.Errorf("failed Datapath_Binding requests = %v, want 1", got)
+	}
+}